@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/gen/models"
+	"github.com/danlock/feedgen/gen/restapi/operations"
+	"github.com/danlock/feedgen/lib"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// UpdateManga replaces the manga, name and description of an editable feed.
+func (s *FgService) UpdateManga(p operations.FeedgenUpdateMangaParams) middleware.Responder {
+	ctx := p.HTTPRequest.Context()
+	feed, resp := s.getOwnedFeed(ctx, p.Hash, p.XOwnerToken)
+	if resp != nil {
+		return resp
+	}
+	if len(p.MangaRequestBody.Titles) == 0 && len(p.MangaRequestBody.Muids) == 0 && len(p.MangaRequestBody.Urls) == 0 {
+		return lib.NewResponse(ctx, http.StatusBadRequest).WithMsg("Titles, muids or urls are required")
+	}
+	fm, resp := s.findFeedManga(ctx, p.MangaRequestBody.Titles, p.MangaRequestBody.Muids, p.MangaRequestBody.Urls)
+	if resp != nil {
+		return resp
+	}
+	partial := fm.partial(p.MangaRequestBody.AllowPartial)
+	if fm.notFound != nil && !partial {
+		return operations.NewFeedgenUpdateMangaNotFound().WithPayload(fm.notFound)
+	}
+	if fm.ambiguous != nil && !partial {
+		return operations.NewFeedgenUpdateMangaConflict().WithPayload(fm.ambiguous)
+	}
+	feed.MUIDs = make([]int64, 0, len(fm.muids))
+	for _, muid := range fm.muids {
+		feed.MUIDs = append(feed.MUIDs, int64(muid))
+	}
+	feed.Name, feed.Description = p.MangaRequestBody.Name, p.MangaRequestBody.Description
+	payload, resp := s.saveFeed(ctx, feed)
+	if resp != nil {
+		return resp
+	}
+	fm.reportSkipped(payload)
+	return operations.NewFeedgenUpdateMangaOK().WithPayload(payload)
+}
+
+// PatchManga adds and removes manga from an editable feed, and changes its name or description if asked to.
+func (s *FgService) PatchManga(p operations.FeedgenPatchMangaParams) middleware.Responder {
+	ctx := p.HTTPRequest.Context()
+	feed, resp := s.getOwnedFeed(ctx, p.Hash, p.XOwnerToken)
+	if resp != nil {
+		return resp
+	}
+	added, resp := s.findFeedManga(ctx, p.MangaPatchBody.AddTitles, p.MangaPatchBody.AddMuids, nil)
+	if resp != nil {
+		return resp
+	}
+	if added.notFound != nil {
+		return operations.NewFeedgenPatchMangaNotFound().WithPayload(added.notFound)
+	}
+	if added.ambiguous != nil {
+		return operations.NewFeedgenPatchMangaConflict().WithPayload(added.ambiguous)
+	}
+	// Every manga matching a removed title is removed, so removing an ambiguous title is never refused
+	removeTitles, removeURLs := splitSeriesURLs(p.MangaPatchBody.RemoveTitles, nil)
+	removed, resp := s.resolveTitles(ctx, removeTitles, maxSuggestedTitles)
+	if resp != nil {
+		return resp
+	}
+	removeMUIDs, unmatchedURLs := s.parseSeriesURLs(ctx, removeURLs)
+	if notFound := titlesNotFound(removed); notFound != nil || len(unmatchedURLs) > 0 {
+		if notFound == nil {
+			notFound = &models.FeedgenTitlesNotFound{Unmatched: []*models.FeedgenUnmatchedTitle{}}
+		}
+		notFound.UnmatchedUrls = unmatchedURLs
+		return operations.NewFeedgenPatchMangaNotFound().WithPayload(notFound)
+	}
+	removeMUIDs = append(removeMUIDs, p.MangaPatchBody.RemoveMuids...)
+	removedSet := make(map[int64]bool, len(removed)+len(removeMUIDs))
+	for _, r := range removed {
+		for _, muid := range r.muids {
+			removedSet[int64(muid)] = true
+		}
+	}
+	for _, muid := range removeMUIDs {
+		removedSet[muid] = true
+	}
+	muids := make([]int64, 0, len(feed.MUIDs)+len(added.muids))
+	for _, muid := range feed.MUIDs {
+		if !removedSet[muid] {
+			muids = append(muids, muid)
+		}
+	}
+	for _, muid := range added.muids {
+		if !removedSet[int64(muid)] {
+			muids = append(muids, int64(muid))
+		}
+	}
+	feed.MUIDs = muids
+	if p.MangaPatchBody.Name != nil {
+		feed.Name = *p.MangaPatchBody.Name
+	}
+	if p.MangaPatchBody.Description != nil {
+		feed.Description = *p.MangaPatchBody.Description
+	}
+	payload, resp := s.saveFeed(ctx, feed)
+	if resp != nil {
+		return resp
+	}
+	return operations.NewFeedgenPatchMangaOK().WithPayload(payload)
+}
+
+// DeleteManga deletes an editable feed.
+func (s *FgService) DeleteManga(p operations.FeedgenDeleteMangaParams) middleware.Responder {
+	ctx := p.HTTPRequest.Context()
+	if _, resp := s.getOwnedFeed(ctx, p.Hash, p.XOwnerToken); resp != nil {
+		return resp
+	}
+	if err := s.mangaStore.DeleteFeed(ctx, p.Hash); err != nil {
+		logger.Errf(ctx, "Failed to delete feed err:%+v", err)
+		return lib.NewResponse(ctx, http.StatusBadGateway)
+	}
+	return operations.NewFeedgenDeleteMangaNoContent()
+}
+
+// getOwnedFeed gets the editable feed identified by hash if ownerToken is its owner token.
+func (s *FgService) getOwnedFeed(ctx context.Context, hash, ownerToken string) (db.MangaFeed, middleware.Responder) {
+	feed := db.MangaFeed{}
+	if err := s.mangaStore.GetFeed(ctx, hash, &feed); err == sql.ErrNoRows {
+		return feed, lib.NewResponse(ctx, http.StatusNotFound)
+	} else if err != nil {
+		logger.Errf(ctx, "Failed to get feed err:%+v", err)
+		return feed, lib.NewResponse(ctx, http.StatusBadGateway)
+	}
+	if !feed.Editable() {
+		return feed, lib.NewResponse(ctx, http.StatusForbidden).WithMsg("This feed can't be edited")
+	}
+	if !feed.OwnedBy(ownerToken) {
+		return feed, lib.NewResponse(ctx, http.StatusForbidden).WithMsg("Wrong owner token")
+	}
+	return feed, nil
+}
+
+// saveFeed saves the changes to an editable feed and describes it for the response.
+func (s *FgService) saveFeed(ctx context.Context, feed db.MangaFeed) (*models.FeedgenMangaFeed, middleware.Responder) {
+	if err := s.mangaStore.UpdateFeed(ctx, feed); err != nil {
+		logger.Errf(ctx, "Failed to update feed err:%+v", err)
+		return nil, lib.NewResponse(ctx, http.StatusBadGateway)
+	}
+	payload, err := s.mangaFeedPayload(feed)
+	if err != nil {
+		logger.Errf(ctx, "Failed to create view manga url err:%+v", err)
+		return nil, lib.NewResponse(ctx, http.StatusInternalServerError)
+	}
+	return payload, nil
+}
+
+// mangaFeedPayload describes feed in API responses, without its owner token.
+func (s *FgService) mangaFeedPayload(feed db.MangaFeed) (*models.FeedgenMangaFeed, error) {
+	viewMangaBuilder := operations.FeedgenViewMangaURL{Hash: feed.Hash}
+	viewMangaURL, err := viewMangaBuilder.BuildFull(s.hostURI.Scheme, s.hostURI.Host)
+	if err != nil {
+		return nil, err
+	}
+	return &models.FeedgenMangaFeed{
+		ID:          feed.Hash,
+		URL:         viewMangaURL.String(),
+		Name:        feed.Name,
+		Description: feed.Description,
+		Editable:    feed.Editable(),
+		Private:     feed.Private,
+		Muids:       []int64(feed.MUIDs),
+	}, nil
+}
@@ -1,85 +1,102 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/gen/models"
 	"github.com/danlock/feedgen/gen/restapi/operations"
 	"github.com/danlock/feedgen/lib"
 	"github.com/danlock/feedgen/lib/logger"
 	"github.com/danlock/feedgen/scrape"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/gorilla/feeds"
+	"github.com/lib/pq"
 )
 
 // feedgen service example implementation.
 // The example methods log the requests and return zero values.
+// Helpers returning a middleware.Responder alongside a value set it when they fail, for the handler to return as is.
 type FgService struct {
 	hostURI    *url.URL
 	mangaStore db.MangaStorer
+	source     scrape.Source
 }
 
 // New returns the feedgen service implementation.
-func NewFeedSrvc(host *url.URL, ms db.MangaStorer) *FgService {
-	return &FgService{host, ms}
+// src is used to link feed items back to the series they were released for.
+func NewFeedSrvc(host *url.URL, ms db.MangaStorer, src scrape.Source) *FgService {
+	return &FgService{host, ms, src}
 }
 func (s *FgService) Manga(p operations.FeedgenMangaParams) middleware.Responder {
 	ctx := p.HTTPRequest.Context()
-	seenTitles := make(map[string]struct{})
-	normalizedTitles := make([]string, 0, len(p.MangaRequestBody.Titles))
-	for _, t := range p.MangaRequestBody.Titles {
-		t = strings.ToLower(strings.TrimSpace(t))
-		if _, seen := seenTitles[t]; seen {
-			continue
-		}
-		seenTitles[t] = struct{}{}
-		normalizedTitles = append(normalizedTitles, t)
+	body := p.MangaRequestBody
+	if len(body.Titles) == 0 && len(body.Muids) == 0 && len(body.Urls) == 0 {
+		return lib.NewResponse(ctx, http.StatusBadRequest).WithMsg("Titles, muids or urls are required")
 	}
-	mangaTitles, err := s.mangaStore.FindMangaByTitlesIntoMangaTitlesSlice(ctx, normalizedTitles)
-	if err != nil {
-		logger.Errf(ctx, "Failed to upsert feed err:%+v", err)
-		return lib.NewResponse(ctx, http.StatusBadGateway)
+	fm, resp := s.findFeedManga(ctx, body.Titles, body.Muids, body.Urls)
+	if resp != nil {
+		return resp
 	}
-	// There could possibly be duplicate titles assigned to different manga, that edge case is not being covered
-	if len(mangaTitles) < len(normalizedTitles) {
-		logger.Warnf(ctx, "Only found %d manga from %d requested titles", len(mangaTitles), len(normalizedTitles))
-		notFoundTitles := make([]string, 0, len(normalizedTitles)-len(mangaTitles))
-		for _, title := range normalizedTitles {
-			found := false
-			for _, foundTitle := range mangaTitles {
-				if title == foundTitle.OriginalTitle {
-					found = true
-					break
-				}
-			}
-			if !found {
-				notFoundTitles = append(notFoundTitles, title)
-			}
-		}
-		return lib.NewResponse(ctx, http.StatusNotFound).WithMsg(fmt.Sprint(notFoundTitles))
+	partial := fm.partial(body.AllowPartial)
+	if fm.notFound != nil && !partial {
+		return operations.NewFeedgenMangaNotFound().WithPayload(fm.notFound)
 	}
-	muids := make([]int, 0, len(mangaTitles))
-	for _, t := range mangaTitles {
-		muids = append(muids, t.MUID)
+	if fm.ambiguous != nil && !partial {
+		return operations.NewFeedgenMangaConflict().WithPayload(fm.ambiguous)
 	}
-	hash, err := s.mangaStore.UpsertFeed(ctx, muids)
-	if err != nil {
+	payload, resp := s.createFeed(ctx, db.NewFeed{
+		MUIDs:       fm.muids,
+		Name:        body.Name,
+		Description: body.Description,
+		Editable:    body.Editable,
+		Private:     body.Private,
+	})
+	if resp != nil {
+		return resp
+	}
+	// Plain feeds are still described by just their URL, unless the caller asked to hear what was left out of them
+	if !body.Editable && !body.Private && !body.AllowPartial {
+		return operations.NewFeedgenMangaOK().WithPayload(payload.URL)
+	}
+	fm.reportSkipped(payload)
+	return operations.NewFeedgenMangaCreated().WithPayload(payload)
+}
+
+// createFeed saves a feed of nf.MUIDs. Plain feeds are shared by everyone requesting the same manga, so they're upserted by their hash.
+func (s *FgService) createFeed(ctx context.Context, nf db.NewFeed) (*models.FeedgenMangaFeed, middleware.Responder) {
+	feed := db.MangaFeed{MUIDs: make([]int64, 0, len(nf.MUIDs))}
+	for _, muid := range nf.MUIDs {
+		feed.MUIDs = append(feed.MUIDs, int64(muid))
+	}
+	var ownerToken string
+	var err error
+	if nf.Editable || nf.Private {
+		if feed.Hash, ownerToken, err = s.mangaStore.CreateFeed(ctx, nf); err != nil {
+			logger.Errf(ctx, "Failed to create feed err:%+v", err)
+			return nil, lib.NewResponse(ctx, http.StatusBadGateway)
+		}
+		feed.Name, feed.Description = nf.Name, nf.Description
+		feed.OwnerTokenHash, feed.Private = sql.NullString{Valid: nf.Editable}, nf.Private
+	} else if feed.Hash, err = s.mangaStore.UpsertFeed(ctx, nf.MUIDs); err != nil {
 		logger.Errf(ctx, "Failed to upsert feed err:%+v", err)
-		return lib.NewResponse(ctx, http.StatusBadGateway)
+		return nil, lib.NewResponse(ctx, http.StatusBadGateway)
 	}
-	viewMangaBuilder := operations.FeedgenViewMangaURL{Hash: hash}
-	viewMangaURL, err := viewMangaBuilder.BuildFull(s.hostURI.Scheme, s.hostURI.Host)
+	payload, err := s.mangaFeedPayload(feed)
 	if err != nil {
 		logger.Errf(ctx, "Failed to create view manga url err:%+v", err)
-		return lib.NewResponse(ctx, http.StatusInternalServerError)
+		return nil, lib.NewResponse(ctx, http.StatusInternalServerError)
 	}
-	return operations.NewFeedgenMangaOK().WithPayload(viewMangaURL.String())
+	payload.OwnerToken = ownerToken
+	return payload, nil
 }
 
 func (s *FgService) ViewManga(p operations.FeedgenViewMangaParams) middleware.Responder {
@@ -91,17 +108,79 @@ func (s *FgService) ViewManga(p operations.FeedgenViewMangaParams) middleware.Re
 	} else if err != nil {
 		logger.Errf(ctx, "Failed to get feed releases err:%+v", err)
 		return lib.NewResponse(ctx, http.StatusBadGateway)
+	} else if len(feed.CollidingMUIDs) > 0 {
+		return s.collidedFeedResponse(ctx, feed, p.FeedType)
+	}
+	limit := int(*p.Limit)
+	filter := db.ReleaseFilter{
+		GroupIDs:        p.Groups,
+		ExcludeGroupIDs: p.ExcludeGroups,
+		Genres:          p.Genres,
+		ExcludeGenres:   p.ExcludeGenres,
+		// One more release than fits on the page tells whether there's another page past it
+		Limit: limit + 1,
+	}
+	var err error
+	if p.Before != nil && p.After != nil {
+		return lib.NewResponse(ctx, http.StatusBadRequest).WithMsg("Only one of before and after can be given")
+	} else if p.Before != nil {
+		filter.Before, err = parseReleaseCursor(*p.Before)
+	} else if p.After != nil {
+		filter.After, err = parseReleaseCursor(*p.After)
 	}
-	releases := make([]db.MangaRelease, 0, len(feed.MUIDs))
-	if err := s.mangaStore.FindReleasesForFeed(ctx, feed, &releases); err != nil {
+	if err != nil {
+		return lib.NewResponse(ctx, http.StatusBadRequest).WithMsg(err.Error())
+	}
+	if p.Since != nil {
+		filter.Since = time.Time(*p.Since)
+	}
+	if p.PerSeries != nil {
+		filter.PerSeries = int(*p.PerSeries)
+	}
+	releases := make([]db.MangaRelease, 0, limit+1)
+	if err := s.mangaStore.FindReleasesForFeed(ctx, feed, filter, &releases); err != nil {
 		logger.Errf(ctx, "Failed to find releases for those titles err:%+v", err)
 		return lib.NewResponse(ctx, http.StatusBadGateway)
 	}
+	// Pages before a cursor always have newer releases, the one of the cursor at least, and pages after one always have older releases
+	hasNewer, hasOlder := filter.Before != nil, filter.After != nil
+	if len(releases) > limit && filter.After != nil {
+		hasNewer, releases = true, releases[1:]
+	} else if len(releases) > limit {
+		hasOlder, releases = true, releases[:limit]
+	}
+	releaseIDs := make([]int64, len(releases))
+	for i := range releases {
+		releaseIDs[i] = releases[i].ID
+	}
+	groupsByRelease, err := s.mangaStore.FindGroupsForReleases(ctx, releaseIDs)
+	if err != nil {
+		logger.Errf(ctx, "Failed to find scanlation groups for releases err:%+v", err)
+		return lib.NewResponse(ctx, http.StatusBadGateway)
+	}
+	for i := range releases {
+		releases[i].Groups = groupsByRelease[releases[i].ID]
+	}
+	metadata, err := s.mangaStore.FindMangaMetadata(ctx, feed.MUIDs)
+	if err != nil {
+		logger.Errf(ctx, "Failed to find manga metadata err:%+v", err)
+		return lib.NewResponse(ctx, http.StatusBadGateway)
+	}
 	if len(releases) == 0 {
 		logger.Dbgf(ctx, "Found no releases for feed %+v, returning empty feed", feed)
 	}
-	viewMangaBuilder := operations.FeedgenViewMangaURL{Hash: p.Hash, FeedType: p.FeedType}
-	viewMangaURL, err := viewMangaBuilder.BuildFull(s.hostURI.Scheme, s.hostURI.Host)
+	fp := feedPage{}
+	if fp.self, err = s.viewMangaPageURL(p, p.Before, p.After); err == nil {
+		fp.first, err = s.viewMangaPageURL(p, nil, nil)
+	}
+	if err == nil && hasNewer && len(releases) > 0 {
+		after := releaseCursor(releases[0])
+		fp.previous, err = s.viewMangaPageURL(p, nil, &after)
+	}
+	if err == nil && hasOlder && len(releases) > 0 {
+		before := releaseCursor(releases[len(releases)-1])
+		fp.next, err = s.viewMangaPageURL(p, &before, nil)
+	}
 	if err != nil {
 		logger.Errf(ctx, "Failed to create view manga url err:%+v", err)
 		return lib.NewResponse(ctx, http.StatusInternalServerError)
@@ -111,18 +190,31 @@ func (s *FgService) ViewManga(p operations.FeedgenViewMangaParams) middleware.Re
 		Description: "This feed has the latest releases for the requested titles from MangaUpdates, if those titles have had a release recent enough to be in the database.",
 		Created:     feed.CreatedAt,
 		Link: &feeds.Link{
-			Href: viewMangaURL.String(),
+			Href: fp.self,
 			Rel:  "self",
 		},
 	}
+	if feed.Name != "" {
+		mangaFeed.Title = feed.Name
+	}
+	if feed.Description != "" {
+		mangaFeed.Description = feed.Description
+	}
 	var latestRelease time.Time
+	releaseByItemID := make(map[string]db.MangaRelease, len(releases))
 	for _, r := range releases {
-		if r.CreatedAt.After(latestRelease) {
-			latestRelease = r.CreatedAt
+		if r.ReleasedAt.After(latestRelease) {
+			latestRelease = r.ReleasedAt
 		}
 		// RSS restricts ID's to valid URL's, but it's important to include the r.Release in the id so the feed can be sorted properly. This is the workaround
 		urlSafeRelease := base64.RawURLEncoding.EncodeToString([]byte(r.Release))
-		uniqueMULink := fmt.Sprintf("%s&release=%s", scrape.GetMUPageURL(r.MUID), urlSafeRelease)
+		uniqueMULink := withQueryParam(s.source.SeriesURL(r.MUID), "release", urlSafeRelease)
+		// Groups releasing the same chapter each get their own item
+		uniqueMULink = withQueryParam(uniqueMULink, "release_id", strconv.FormatInt(r.ID, 10))
+		content := fmt.Sprintf("%s %s released and translated by %s", r.Title, r.Release, r.Translators)
+		if summary := seriesSummary(metadata[r.MUID]); summary != "" {
+			content += ". " + summary
+		}
 		l := &feeds.Link{
 			Href: uniqueMULink,
 			Rel:  "self",
@@ -130,13 +222,14 @@ func (s *FgService) ViewManga(p operations.FeedgenViewMangaParams) middleware.Re
 		it := &feeds.Item{
 			Id:          uniqueMULink,
 			Title:       fmt.Sprintf("%s %s", r.Title, r.Release),
-			Content:     fmt.Sprintf("%s %s released and translated by %s", r.Title, r.Release, r.Translators),
-			Description: fmt.Sprintf("%s %s released and translated by %s", r.Title, r.Release, r.Translators),
-			Created:     r.CreatedAt,
-			Updated:     r.CreatedAt,
+			Content:     content,
+			Description: content,
+			Created:     r.ReleasedAt,
+			Updated:     r.ReleasedAt,
 			Link:        l,
 			Source:      l,
 			Author:      &feeds.Author{Name: r.Translators},
+			Enclosure:   s.coverEnclosure(metadata[r.MUID]),
 		}
 		// RSS restricts authors to valid emails only
 		if *p.FeedType == "rss" {
@@ -144,20 +237,31 @@ func (s *FgService) ViewManga(p operations.FeedgenViewMangaParams) middleware.Re
 			it.Source = nil
 		}
 		mangaFeed.Add(it)
+		releaseByItemID[it.Id] = r
 	}
 	mangaFeed.Updated = latestRelease
-	mangaFeed.Sort(func(a, b *feeds.Item) bool { return a.Id < b.Id })
+	// Newest releases first, with releases from the same day in chapter order
+	mangaFeed.Sort(func(a, b *feeds.Item) bool {
+		if !a.Created.Equal(b.Created) {
+			return a.Created.After(b.Created)
+		}
+		ra, rb := releaseByItemID[a.Id], releaseByItemID[b.Id]
+		if ra.MUID != rb.MUID {
+			return ra.Title < rb.Title
+		}
+		return ra.SortKey() > rb.SortKey()
+	})
 	var result string
 	switch *p.FeedType {
 	case "atom":
 		p.HTTPRequest.Header.Set("Accept", "application/xml")
-		result, err = mangaFeed.ToAtom()
+		result, err = toPagedAtom(&mangaFeed, fp)
 	case "rss":
 		p.HTTPRequest.Header.Set("Accept", "application/xml")
-		result, err = mangaFeed.ToRss()
+		result, err = toPagedRss(&mangaFeed, fp)
 	case "json":
 		p.HTTPRequest.Header.Set("Accept", "application/json")
-		result, err = mangaFeed.ToJSON()
+		result, err = toJSONFeed(&mangaFeed, fp.next, releaseByItemID, metadata)
 	default:
 		logger.Errf(ctx, "Received unsupported field type %s", *p.FeedType)
 		return lib.NewResponse(ctx, http.StatusInternalServerError)
@@ -170,6 +274,93 @@ func (s *FgService) ViewManga(p operations.FeedgenViewMangaParams) middleware.Re
 	return operations.NewFeedgenViewMangaOK().WithPayload(result)
 }
 
+// defaultFeedLimit is the default of the limit parameter of /api/feed/manga/{hash}
+const defaultFeedLimit = 50
+
+// viewMangaPageURL links to the page of the feed p requested before or after a cursor, leaving out defaults like feeds did before paging.
+func (s *FgService) viewMangaPageURL(p operations.FeedgenViewMangaParams, before, after *string) (string, error) {
+	b := operations.FeedgenViewMangaURL{
+		Hash:          p.Hash,
+		FeedType:      p.FeedType,
+		Groups:        p.Groups,
+		ExcludeGroups: p.ExcludeGroups,
+		Genres:        p.Genres,
+		ExcludeGenres: p.ExcludeGenres,
+		Since:         p.Since,
+		PerSeries:     p.PerSeries,
+		Before:        before,
+		After:         after,
+	}
+	if *p.Limit != defaultFeedLimit {
+		b.Limit = p.Limit
+	}
+	u, err := b.BuildFull(s.hostURI.Scheme, s.hostURI.Host)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// collidedFeedResponse refuses a legacy feed whose hash was also given out for another set of manga, linking to the feed of each set instead.
+func (s *FgService) collidedFeedResponse(ctx context.Context, feed db.MangaFeed, feedType *string) middleware.Responder {
+	links := make([]string, 0, 2)
+	for _, muids := range []pq.Int64Array{feed.MUIDs, feed.CollidingMUIDs} {
+		ints := make([]int, len(muids))
+		for i, muid := range muids {
+			ints[i] = int(muid)
+		}
+		hash, err := s.mangaStore.UpsertFeed(ctx, ints)
+		if err != nil {
+			logger.Errf(ctx, "Failed to upsert feed for collided feed %s err:%+v", feed.Hash, err)
+			return lib.NewResponse(ctx, http.StatusBadGateway)
+		}
+		u, err := (&operations.FeedgenViewMangaURL{Hash: hash, FeedType: feedType}).BuildFull(s.hostURI.Scheme, s.hostURI.Host)
+		if err != nil {
+			logger.Errf(ctx, "Failed to create view manga url err:%+v", err)
+			return lib.NewResponse(ctx, http.StatusInternalServerError)
+		}
+		links = append(links, fmt.Sprintf("%s for manga %v", u, []int64(muids)))
+	}
+	logger.Warnf(ctx, "Refused feed %s, whose hash was given out for both %s", feed.Hash, strings.Join(links, " and "))
+	return lib.NewResponse(ctx, http.StatusConflict).WithMsg(fmt.Sprintf(
+		"This feed's link was given out for two different sets of manga, so it can't tell which one to serve. Use %s instead", strings.Join(links, " or ")))
+}
+
+// withQueryParam sets a query parameter on rawURL, leaving rawURL unchanged if it can't be parsed.
+func withQueryParam(rawURL, key, value string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// seriesSummary describes a manga's metadata in a sentence or two for feed item content.
+func seriesSummary(mm db.MangaMetadata) string {
+	sentences := make([]string, 0, 3)
+	kind := strings.TrimSpace(strings.Join([]string{mm.Type, mm.Status}, " "))
+	if mm.Year > 0 {
+		kind = strings.TrimSpace(fmt.Sprintf("%s from %d", kind, mm.Year))
+	}
+	if kind != "" {
+		sentences = append(sentences, kind)
+	}
+	if len(mm.Authors) > 0 {
+		byline := "By " + strings.Join(mm.Authors, ", ")
+		if len(mm.Artists) > 0 {
+			byline += ", art by " + strings.Join(mm.Artists, ", ")
+		}
+		sentences = append(sentences, byline)
+	}
+	if len(mm.Genres) > 0 {
+		sentences = append(sentences, "Genres: "+strings.Join(mm.Genres, ", "))
+	}
+	return strings.Join(sentences, ". ")
+}
+
 func (s *FgService) ViewMangaTitles(p operations.FeedgenViewMangaTitlesParams) middleware.Responder {
 	ctx := p.HTTPRequest.Context()
 
@@ -179,6 +370,8 @@ func (s *FgService) ViewMangaTitles(p operations.FeedgenViewMangaTitlesParams) m
 	} else if err != nil {
 		logger.Errf(ctx, "Failed to get feed muids err:%+v", err)
 		return lib.NewResponse(ctx, http.StatusBadGateway)
+	} else if len(feed.CollidingMUIDs) > 0 {
+		return s.collidedFeedResponse(ctx, feed, nil)
 	}
 	manga := make([]db.MangaTitle, 0)
 	if err := s.mangaStore.FindMangaByMUIDs(ctx, feed.MUIDs, &manga); err != nil {
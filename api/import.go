@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/gen/models"
+	"github.com/danlock/feedgen/gen/restapi/operations"
+	"github.com/danlock/feedgen/lib"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/readinglist"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+// maxImportEntries is how many manga an imported reading list can hold, the most a feed can be requested with
+const maxImportEntries = 2048
+
+// ImportManga creates a feed of the manga on an exported reading list, listing the entries left out of it.
+func (s *FgService) ImportManga(p operations.FeedgenImportMangaParams) middleware.Responder {
+	ctx := p.HTTPRequest.Context()
+	body := p.ImportRequestBody
+	entries, err := parseReadingList(decodeExport(*body.Export), readinglist.Format(body.Format))
+	if err != nil {
+		logger.Warnf(ctx, "Failed to read %s reading list err:%+v", body.Format, err)
+		return lib.NewResponse(ctx, http.StatusBadRequest).WithMsg(err.Error())
+	}
+	fm, resp := s.matchReadingList(ctx, entries)
+	if resp != nil {
+		return resp
+	}
+	if len(fm.muids) == 0 && fm.ambiguous != nil {
+		return operations.NewFeedgenImportMangaConflict().WithPayload(fm.ambiguous)
+	} else if len(fm.muids) == 0 {
+		return operations.NewFeedgenImportMangaNotFound().WithPayload(fm.notFound)
+	}
+	payload, resp := s.createFeed(ctx, db.NewFeed{
+		MUIDs:       fm.muids,
+		Name:        body.Name,
+		Description: body.Description,
+		Editable:    body.Editable,
+		Private:     body.Private,
+	})
+	if resp != nil {
+		return resp
+	}
+	fm.reportSkipped(payload)
+	return operations.NewFeedgenImportMangaCreated().WithPayload(payload)
+}
+
+// ImportReadingList creates a feed like ImportManga does, from a reading list export read from elsewhere than a request.
+func (s *FgService) ImportReadingList(ctx context.Context, data []byte, format readinglist.Format, nf db.NewFeed) (*models.FeedgenMangaFeed, error) {
+	entries, err := parseReadingList(data, format)
+	if err != nil {
+		return nil, err
+	}
+	fm, resp := s.matchReadingList(ctx, entries)
+	if resp != nil {
+		return nil, errors.New("Failed to match the reading list to manga")
+	}
+	payload := &models.FeedgenMangaFeed{}
+	if len(fm.muids) > 0 {
+		nf.MUIDs = fm.muids
+		if payload, resp = s.createFeed(ctx, nf); resp != nil {
+			return nil, errors.New("Failed to create the feed")
+		}
+	}
+	fm.reportSkipped(payload)
+	return payload, nil
+}
+
+// decodeExport returns the bytes of an export sent in a request, which are base64 encoded if the export is gzipped.
+// Plain exports start with < or { and so are never valid base64.
+func decodeExport(export string) []byte {
+	if data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(export)); err == nil {
+		return data
+	}
+	return []byte(export)
+}
+
+// parseReadingList reads the entries of a reading list export, refusing exports without any manga or with more than a feed can hold.
+func parseReadingList(data []byte, format readinglist.Format) ([]readinglist.Entry, error) {
+	entries, err := readinglist.Parse(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("Export holds no manga")
+	}
+	if len(entries) > maxImportEntries {
+		return nil, errors.Errorf("Export holds %d manga, more than the %d a feed can hold", len(entries), maxImportEntries)
+	}
+	return entries, nil
+}
+
+// matchReadingList finds the manga of each entry by the MangaUpdates series it cross references, then by the most of its titles matching one.
+func (s *FgService) matchReadingList(ctx context.Context, entries []readinglist.Entry) (feedManga, middleware.Responder) {
+	fm := feedManga{muids: make([]int, 0, len(entries))}
+	// Cross references are trusted over titles, which different manga can share
+	matched := make([]int, len(entries))
+	urlMUIDs := make([]int64, 0)
+	urlEntries := make([]int, 0)
+	for i, e := range entries {
+		muids, _ := s.parseSeriesURLs(ctx, e.URLs)
+		for _, muid := range muids {
+			urlMUIDs = append(urlMUIDs, muid)
+			urlEntries = append(urlEntries, i)
+		}
+	}
+	current, _, resp := s.findOrScrapeManga(ctx, urlMUIDs)
+	if resp != nil {
+		return fm, resp
+	}
+	for i, muid := range current {
+		if e := urlEntries[i]; muid > 0 && matched[e] == 0 {
+			matched[e] = muid
+		}
+	}
+
+	titles := make([]string, 0, len(entries))
+	for i, e := range entries {
+		if matched[i] == 0 {
+			titles = append(titles, e.Titles...)
+		}
+	}
+	// Candidates are only found for each entry's main title below, rather than for every title that matched nothing
+	resolutions, resp := s.resolveTitles(ctx, titles, 0)
+	if resp != nil {
+		return fm, resp
+	}
+	byTitle := make(map[string]titleResolution, len(resolutions))
+	for _, r := range resolutions {
+		byTitle[normalizeTitle(r.title)] = r
+	}
+
+	seen := make(map[int]bool, len(entries))
+	add := func(muid int) {
+		if !seen[muid] {
+			seen[muid] = true
+			fm.muids = append(fm.muids, muid)
+		}
+	}
+	unmatched, ambiguous := make([]titleResolution, 0), make([]titleResolution, 0)
+	suggested := 0
+	for i, e := range entries {
+		if matched[i] > 0 {
+			add(matched[i])
+			continue
+		}
+		r := titleResolution{entry: e.ID, muids: mostMatched(e.Titles, byTitle)}
+		if len(e.Titles) > 0 {
+			r.title = e.Titles[0]
+		} else {
+			r.title = e.URLs[0]
+		}
+		switch r.status() {
+		case models.FeedgenTitleResolutionStatusMatched:
+			add(r.muids[0])
+		case models.FeedgenTitleResolutionStatusAmbiguous:
+			ambiguous = append(ambiguous, r)
+		default:
+			if len(e.Titles) > 0 && suggested < maxSuggestedTitles {
+				suggested++
+				var err error
+				if r.candidates, err = s.mangaStore.FindTitleCandidates(ctx, normalizeTitle(r.title), maxTitleCandidates); err != nil {
+					logger.Errf(ctx, "Failed to find title candidates err:%+v", err)
+					return fm, lib.NewResponse(ctx, http.StatusBadGateway)
+				}
+			}
+			unmatched = append(unmatched, r)
+		}
+	}
+	logger.Infof(ctx, "Matched %d of %d reading list entries, %d ambiguously", len(entries)-len(unmatched)-len(ambiguous), len(entries), len(ambiguous))
+
+	fm.notFound = titlesNotFound(unmatched)
+	if len(ambiguous) > 0 {
+		fm.ambiguous, resp = s.resolutionsPayload(ctx, ambiguous)
+	}
+	return fm, resp
+}
+
+// mostMatched returns the manga matched by the most of titles, more than one if they're tied.
+func mostMatched(titles []string, byTitle map[string]titleResolution) []int {
+	votes := make(map[int]int)
+	order := make([]int, 0)
+	most := 0
+	for _, t := range titles {
+		for _, muid := range byTitle[normalizeTitle(t)].muids {
+			if votes[muid] == 0 {
+				order = append(order, muid)
+			}
+			if votes[muid]++; votes[muid] > most {
+				most = votes[muid]
+			}
+		}
+	}
+	top := make([]int, 0, 1)
+	for _, muid := range order {
+		if votes[muid] == most {
+			top = append(top, muid)
+		}
+	}
+	return top
+}
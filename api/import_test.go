@@ -0,0 +1,29 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/danlock/feedgen/readinglist"
+)
+
+func TestDecodeExport(t *testing.T) {
+	export := `<myanimelist><manga><manga_mangadb_id>2</manga_mangadb_id><manga_title>Berserk</manga_title></manga></myanimelist>`
+	zipped := bytes.Buffer{}
+	zw := gzip.NewWriter(&zipped)
+	zw.Write([]byte(export))
+	zw.Close()
+
+	want := []readinglist.Entry{{ID: "myanimelist:2", Titles: []string{"Berserk"}}}
+	for _, sent := range []string{export, base64.StdEncoding.EncodeToString([]byte(export)), base64.StdEncoding.EncodeToString(zipped.Bytes())} {
+		got, err := readinglist.Parse(decodeExport(sent), readinglist.MyAnimeList)
+		if err != nil {
+			t.Errorf("Parse of %q failed: %+v", sent, err)
+		} else if !reflect.DeepEqual(got, want) {
+			t.Errorf("Parse of %q returned %+v, want %+v", sent, got, want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/gorilla/feeds"
+	"github.com/pkg/errors"
+)
+
+// jsonFeedRelease is a JSON Feed extension with the structured release info of an item.
+// JSON Feed requires extension keys to start with an underscore.
+type jsonFeedRelease struct {
+	Release     string               `json:"release"`
+	Translators string               `json:"translators,omitempty"`
+	Groups      []db.ScanlationGroup `json:"groups,omitempty"`
+	Series      *jsonFeedSeries      `json:"series,omitempty"`
+	scrape.ReleaseInfo
+}
+
+// jsonFeedSeries is the metadata of the series an item was released for.
+type jsonFeedSeries struct {
+	MUID       int      `json:"muid"`
+	Type       string   `json:"type,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Year       int      `json:"year,omitempty"`
+	Authors    []string `json:"authors,omitempty"`
+	Artists    []string `json:"artists,omitempty"`
+	Genres     []string `json:"genres,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+type jsonFeedItem struct {
+	*feeds.JSONItem
+	Feedgen *jsonFeedRelease `json:"_feedgen,omitempty"`
+}
+
+type jsonFeed struct {
+	*feeds.JSONFeed
+	Items []*jsonFeedItem `json:"items,omitempty"`
+}
+
+// toJSONFeed is feeds.Feed.ToJSON with the release info and series metadata of each item, linking to nextURL if it's set.
+func toJSONFeed(f *feeds.Feed, nextURL string, releases map[string]db.MangaRelease, metadata map[int]db.MangaMetadata) (string, error) {
+	jf := jsonFeed{JSONFeed: (&feeds.JSON{Feed: f}).JSONFeed()}
+	jf.NextUrl = nextURL
+	for _, it := range jf.JSONFeed.Items {
+		jit := &jsonFeedItem{JSONItem: it}
+		if r, ok := releases[it.Id]; ok {
+			jit.Feedgen = &jsonFeedRelease{Release: r.Release, Translators: r.Translators, Groups: r.Groups, ReleaseInfo: r.ReleaseInfo}
+			if mm, ok := metadata[r.MUID]; ok {
+				jit.Tags = mm.Genres
+				jit.Feedgen.Series = &jsonFeedSeries{
+					MUID:       mm.MUID,
+					Type:       mm.Type,
+					Status:     mm.Status,
+					Year:       mm.Year,
+					Authors:    mm.Authors,
+					Artists:    mm.Artists,
+					Genres:     mm.Genres,
+					Categories: mm.Categories,
+				}
+			}
+		}
+		jf.Items = append(jf.Items, jit)
+	}
+	data, err := json.MarshalIndent(jf, "", "  ")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(data), nil
+}
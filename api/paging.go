@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/lib"
+	"github.com/gorilla/feeds"
+	"github.com/pkg/errors"
+)
+
+// feedPage links a page of a feed to the newer (previous) and older (next) ones as an RFC 5005 paged feed.
+// They aren't archives, since releases backfilled later land in pages that were already served.
+type feedPage struct {
+	self, first, previous, next string
+}
+
+// atomLinks returns the page's links, which Atom and RSS both list as atom:link elements.
+func (fp feedPage) atomLinks() []feeds.AtomLink {
+	links := []feeds.AtomLink{{Href: fp.self, Rel: "self"}, {Href: fp.first, Rel: "first"}}
+	if fp.previous != "" {
+		links = append(links, feeds.AtomLink{Href: fp.previous, Rel: "previous"})
+	}
+	if fp.next != "" {
+		links = append(links, feeds.AtomLink{Href: fp.next, Rel: "next"})
+	}
+	return links
+}
+
+// ErrInvalidCursor is returned for a before or after parameter that wasn't made by releaseCursor.
+const ErrInvalidCursor lib.SentinelError = "Invalid release cursor"
+
+// releaseCursor returns the position of r in its feed, for the before and after parameters.
+func releaseCursor(r db.MangaRelease) string {
+	return fmt.Sprintf("%d_%d", r.ReleasedAt.UnixNano(), r.ID)
+}
+
+// parseReleaseCursor reads a cursor returned by releaseCursor.
+func parseReleaseCursor(cursor string) (*db.ReleaseCursor, error) {
+	parts := strings.Split(cursor, "_")
+	if len(parts) != 2 {
+		return nil, ErrInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &db.ReleaseCursor{ReleasedAt: time.Unix(0, nanos).UTC(), ID: id}, nil
+}
+
+// pagedAtom replaces the single link gorilla/feeds gives an Atom feed with every link of its page.
+type pagedAtom struct {
+	*feeds.AtomFeed
+	Links []feeds.AtomLink `xml:"link"`
+}
+
+// rssLink is an atom:link inside an RSS channel.
+type rssLink struct {
+	XMLName xml.Name `xml:"atom:link"`
+	Href    string   `xml:"href,attr"`
+	Rel     string   `xml:"rel,attr"`
+}
+
+type pagedRssChannel struct {
+	*feeds.RssFeed
+	Links []rssLink
+}
+
+// pagedRss is feeds.RssFeedXml with the atom namespace declared, so the channel can link to the other pages.
+type pagedRss struct {
+	XMLName          xml.Name `xml:"rss"`
+	Version          string   `xml:"version,attr"`
+	ContentNamespace string   `xml:"xmlns:content,attr"`
+	AtomNamespace    string   `xml:"xmlns:atom,attr"`
+	Channel          *pagedRssChannel
+}
+
+// toPagedAtom is feeds.Feed.ToAtom, but linking to the other pages of the feed.
+func toPagedAtom(f *feeds.Feed, fp feedPage) (string, error) {
+	a := pagedAtom{AtomFeed: (&feeds.Atom{Feed: f}).AtomFeed(), Links: fp.atomLinks()}
+	a.Link = nil
+	return toXML(a)
+}
+
+// toPagedRss is feeds.Feed.ToRss, but linking to the other pages of the feed.
+func toPagedRss(f *feeds.Feed, fp feedPage) (string, error) {
+	channel := &pagedRssChannel{RssFeed: (&feeds.Rss{Feed: f}).RssFeed()}
+	for _, l := range fp.atomLinks() {
+		channel.Links = append(channel.Links, rssLink{Href: l.Href, Rel: l.Rel})
+	}
+	return toXML(pagedRss{
+		Version:          "2.0",
+		ContentNamespace: "http://purl.org/rss/1.0/modules/content/",
+		AtomNamespace:    "http://www.w3.org/2005/Atom",
+		Channel:          channel,
+	})
+}
+
+// toXML encodes v the way gorilla/feeds does.
+func toXML(v interface{}) (string, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	// strip the trailing newline from the header, like gorilla/feeds
+	return xml.Header[:len(xml.Header)-1] + string(data), nil
+}
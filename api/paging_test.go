@@ -0,0 +1,79 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/gorilla/feeds"
+)
+
+func TestPagedFeedLinks(t *testing.T) {
+	f := &feeds.Feed{Title: "Feed", Link: &feeds.Link{Href: "https://feedgen.example/api/feed/manga/abc"}, Created: time.Date(2023, 12, 8, 0, 0, 0, 0, time.UTC)}
+	f.Add(&feeds.Item{Id: "https://feedgen.example/item", Title: "Item", Link: &feeds.Link{Href: "https://feedgen.example/item"}, Created: f.Created})
+	fp := feedPage{
+		self:     "https://feedgen.example/api/feed/manga/abc?before=2_2",
+		first:    "https://feedgen.example/api/feed/manga/abc",
+		previous: "https://feedgen.example/api/feed/manga/abc?after=1_1",
+		next:     "https://feedgen.example/api/feed/manga/abc?before=0_3",
+	}
+	atom, err := toPagedAtom(f, fp)
+	if err != nil {
+		t.Fatalf("toPagedAtom failed: %+v", err)
+	}
+	rss, err := toPagedRss(f, fp)
+	if err != nil {
+		t.Fatalf("toPagedRss failed: %+v", err)
+	}
+	wantLinks := []struct{ rel, href string }{
+		{"self", fp.self},
+		{"first", fp.first},
+		{"previous", fp.previous},
+		{"next", fp.next},
+	}
+	for _, l := range wantLinks {
+		if want := `<link href="` + l.href + `" rel="` + l.rel + `"></link>`; !strings.Contains(atom, want) {
+			t.Errorf("Atom feed is missing %s in\n%s", want, atom)
+		}
+		if want := `<atom:link href="` + l.href + `" rel="` + l.rel + `"></atom:link>`; !strings.Contains(rss, want) {
+			t.Errorf("RSS feed is missing %s in\n%s", want, rss)
+		}
+	}
+	if !strings.Contains(rss, `xmlns:atom="http://www.w3.org/2005/Atom"`) {
+		t.Errorf("RSS feed doesn't declare the atom namespace in\n%s", rss)
+	}
+	// Backfilled releases change pages already served, so they mustn't be advertised as archives
+	for _, rel := range []string{"current", "prev-archive", "next-archive"} {
+		if strings.Contains(atom, `rel="`+rel+`"`) || strings.Contains(rss, `rel="`+rel+`"`) {
+			t.Errorf("Paged feed links to a %s archive", rel)
+		}
+	}
+
+	first := feedPage{self: fp.first, first: fp.first}
+	atom, err = toPagedAtom(f, first)
+	if err != nil {
+		t.Fatalf("toPagedAtom failed: %+v", err)
+	}
+	for _, rel := range []string{"previous", "next"} {
+		if strings.Contains(atom, `rel="`+rel+`"`) {
+			t.Errorf("Atom feed that fits on one page links to a %s page in\n%s", rel, atom)
+		}
+	}
+}
+
+func TestReleaseCursor(t *testing.T) {
+	r := db.MangaRelease{ID: 42, ReleasedAt: time.Date(2023, 12, 8, 13, 14, 15, 16000, time.UTC)}
+	got, err := parseReleaseCursor(releaseCursor(r))
+	if err != nil {
+		t.Fatalf("parseReleaseCursor failed: %+v", err)
+	}
+	if got.ID != r.ID || !got.ReleasedAt.Equal(r.ReleasedAt) {
+		t.Errorf("Cursor of release %d released at %s parsed as %+v", r.ID, r.ReleasedAt, got)
+	}
+	for _, cursor := range []string{"", "1", "1_", "_1", "a_1", "1_b", "1_2_3"} {
+		if _, err := parseReleaseCursor(cursor); err != ErrInvalidCursor {
+			t.Errorf("parseReleaseCursor(%q) returned err %v, want %v", cursor, err, ErrInvalidCursor)
+		}
+	}
+}
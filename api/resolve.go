@@ -0,0 +1,312 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/gen/models"
+	"github.com/danlock/feedgen/gen/restapi/operations"
+	"github.com/danlock/feedgen/lib"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/lib/pq"
+)
+
+const (
+	// maxSuggestedTitles is how many unmatched titles are given candidates, since finding them takes a query each
+	maxSuggestedTitles = 50
+	// maxTitleCandidates is how many candidates are suggested for each unmatched title
+	maxTitleCandidates = 5
+)
+
+// titleResolution is what a requested title matched.
+type titleResolution struct {
+	// title is the title the first way it was requested
+	title string
+	// muids are the manga with the title, more than one if it's ambiguous
+	muids []int
+	// candidates are the manga with the most alike titles if no manga has the title
+	candidates []db.TitleCandidate
+	// entry is the imported reading list entry the title is from, if any
+	entry string
+}
+
+func (r titleResolution) status() string {
+	switch len(r.muids) {
+	case 0:
+		return models.FeedgenTitleResolutionStatusNotFound
+	case 1:
+		return models.FeedgenTitleResolutionStatusMatched
+	default:
+		return models.FeedgenTitleResolutionStatusAmbiguous
+	}
+}
+
+// ResolveManga returns what each requested title matches, so the ambiguous ones can be settled with muids when creating a feed.
+func (s *FgService) ResolveManga(p operations.FeedgenResolveMangaParams) middleware.Responder {
+	ctx := p.HTTPRequest.Context()
+	resolutions, resp := s.resolveTitles(ctx, p.ResolveRequestBody.Titles, maxSuggestedTitles)
+	if resp != nil {
+		return resp
+	}
+	payload, resp := s.resolutionsPayload(ctx, resolutions)
+	if resp != nil {
+		return resp
+	}
+	return operations.NewFeedgenResolveMangaOK().WithPayload(payload)
+}
+
+// resolveTitles finds the manga titled each of titles, suggesting candidates for up to maxSuggested titles that match none.
+func (s *FgService) resolveTitles(ctx context.Context, titles []string, maxSuggested int) ([]titleResolution, middleware.Responder) {
+	// requested keeps the first way each title was written, to report titles as they were requested
+	requested := make(map[string]string)
+	normalizedTitles := make([]string, 0, len(titles))
+	for _, t := range titles {
+		normalized := normalizeTitle(t)
+		if _, seen := requested[normalized]; seen {
+			continue
+		}
+		requested[normalized] = t
+		normalizedTitles = append(normalizedTitles, normalized)
+	}
+	if len(normalizedTitles) == 0 {
+		return nil, nil
+	}
+	mangaTitles, err := s.mangaStore.FindMangaByTitlesIntoMangaTitlesSlice(ctx, normalizedTitles)
+	if err != nil {
+		logger.Errf(ctx, "Failed to find manga by titles err:%+v", err)
+		return nil, lib.NewResponse(ctx, http.StatusBadGateway)
+	}
+	// There could possibly be duplicate titles assigned to different manga, every one of them is kept
+	matches := make(map[string][]int, len(mangaTitles))
+	for _, t := range mangaTitles {
+		matches[t.OriginalTitle] = appendUniqueMUID(matches[t.OriginalTitle], t.MUID)
+	}
+
+	folded := make(map[string]string)
+	seenFolded := make(map[string]bool)
+	foldedTitles := make([]string, 0)
+	for _, t := range normalizedTitles {
+		if len(matches[t]) > 0 {
+			continue
+		}
+		f := db.FoldTitle(t)
+		if f != "" && !seenFolded[f] {
+			seenFolded[f] = true
+			foldedTitles = append(foldedTitles, f)
+		}
+		folded[t] = f
+	}
+	foldedMatches := make(map[string][]int, len(foldedTitles))
+	if len(foldedTitles) > 0 {
+		foldedManga, err := s.mangaStore.FindMangaByFoldedTitles(ctx, foldedTitles)
+		if err != nil {
+			logger.Errf(ctx, "Failed to find manga by folded titles err:%+v", err)
+			return nil, lib.NewResponse(ctx, http.StatusBadGateway)
+		}
+		for _, t := range foldedManga {
+			foldedMatches[t.OriginalTitle] = appendUniqueMUID(foldedMatches[t.OriginalTitle], t.MUID)
+		}
+	}
+
+	resolutions := make([]titleResolution, 0, len(normalizedTitles))
+	suggested, unmatched := 0, 0
+	for _, t := range normalizedTitles {
+		r := titleResolution{title: requested[t], muids: matches[t]}
+		if len(r.muids) == 0 {
+			r.muids = foldedMatches[folded[t]]
+		}
+		if len(r.muids) == 0 {
+			unmatched++
+		}
+		if len(r.muids) == 0 && suggested < maxSuggested {
+			suggested++
+			if r.candidates, err = s.mangaStore.FindTitleCandidates(ctx, t, maxTitleCandidates); err != nil {
+				logger.Errf(ctx, "Failed to find title candidates err:%+v", err)
+				return nil, lib.NewResponse(ctx, http.StatusBadGateway)
+			}
+		}
+		resolutions = append(resolutions, r)
+	}
+	if unmatched > 0 {
+		logger.Warnf(ctx, "Only found manga for %d of %d requested titles", len(normalizedTitles)-unmatched, len(normalizedTitles))
+	}
+	return resolutions, nil
+}
+
+// normalizeTitle is how titles are compared with mangatitle.title, which is stored lowercased.
+func normalizeTitle(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
+
+// feedManga is the manga requested for a feed.
+type feedManga struct {
+	muids []int
+	// notFound is set if a title matched no manga or a muid isn't in the db
+	notFound *models.FeedgenTitlesNotFound
+	// ambiguous is set if a title matched more than one manga and none of them were requested by muid
+	ambiguous *models.FeedgenResolveResponse
+}
+
+// findFeedManga finds the manga requested for a feed by titles, muids and MangaUpdates series urls.
+// A title matching more than one manga stands for those of them also requested by muid, and is ambiguous if none of them are.
+func (s *FgService) findFeedManga(ctx context.Context, titles []string, muids []int64, urls []string) (feedManga, middleware.Responder) {
+	titles, urls = splitSeriesURLs(titles, urls)
+	urlMUIDs, unmatchedURLs := s.parseSeriesURLs(ctx, urls)
+	muids = append(append(make([]int64, 0, len(muids)+len(urlMUIDs)), muids...), urlMUIDs...)
+
+	fm := feedManga{muids: make([]int, 0, len(titles)+len(muids))}
+	current, unknown, resp := s.findOrScrapeManga(ctx, muids)
+	if resp != nil {
+		return fm, resp
+	}
+	requested := make(map[int]bool, len(current))
+	seen := make(map[int]bool, len(titles)+len(current))
+	add := func(muid int) {
+		if !seen[muid] {
+			seen[muid] = true
+			fm.muids = append(fm.muids, muid)
+		}
+	}
+	for _, muid := range current {
+		if muid > 0 {
+			requested[muid] = true
+			add(muid)
+		}
+	}
+
+	resolutions, resp := s.resolveTitles(ctx, titles, maxSuggestedTitles)
+	if resp != nil {
+		return fm, resp
+	}
+	ambiguous := make([]titleResolution, 0)
+	for _, r := range resolutions {
+		if r.status() != models.FeedgenTitleResolutionStatusAmbiguous {
+			for _, muid := range r.muids {
+				add(muid)
+			}
+			continue
+		}
+		picked := false
+		for _, muid := range r.muids {
+			picked = picked || requested[muid]
+		}
+		if !picked {
+			ambiguous = append(ambiguous, r)
+		}
+	}
+
+	if fm.notFound = titlesNotFound(resolutions); fm.notFound != nil || len(unknown) > 0 || len(unmatchedURLs) > 0 {
+		if fm.notFound == nil {
+			fm.notFound = &models.FeedgenTitlesNotFound{Unmatched: []*models.FeedgenUnmatchedTitle{}}
+		}
+		fm.notFound.UnknownMuids = unknown
+		fm.notFound.UnmatchedUrls = unmatchedURLs
+	}
+	if len(ambiguous) > 0 {
+		logger.Warnf(ctx, "%d of %d requested titles match more than one manga", len(ambiguous), len(resolutions))
+		fm.ambiguous, resp = s.resolutionsPayload(ctx, ambiguous)
+	}
+	return fm, resp
+}
+
+// partial tells whether a feed requested with allowPartial can be made despite what wasn't found, because something was.
+func (fm feedManga) partial(allowPartial bool) bool {
+	return allowPartial && len(fm.muids) > 0
+}
+
+// reportSkipped lists the requested manga left out of a feed made with allowPartial in its payload.
+func (fm feedManga) reportSkipped(payload *models.FeedgenMangaFeed) {
+	if fm.notFound != nil {
+		payload.Unresolved = fm.notFound.Unmatched
+		payload.UnknownMuids = fm.notFound.UnknownMuids
+		payload.UnmatchedUrls = fm.notFound.UnmatchedUrls
+	}
+	if fm.ambiguous != nil {
+		payload.Ambiguous = fm.ambiguous.Titles
+	}
+}
+
+// titlesNotFound reports the resolutions that matched no manga, or returns nil if they all matched.
+func titlesNotFound(resolutions []titleResolution) *models.FeedgenTitlesNotFound {
+	notFound := &models.FeedgenTitlesNotFound{}
+	for _, r := range resolutions {
+		if r.status() == models.FeedgenTitleResolutionStatusNotFound {
+			notFound.Unmatched = append(notFound.Unmatched, &models.FeedgenUnmatchedTitle{Title: r.title, Entry: r.entry, Candidates: titleCandidatesPayload(r.candidates)})
+		}
+	}
+	if len(notFound.Unmatched) == 0 {
+		return nil
+	}
+	return notFound
+}
+
+// resolutionsPayload describes resolutions in API responses, along with the metadata of each manga they matched.
+func (s *FgService) resolutionsPayload(ctx context.Context, resolutions []titleResolution) (*models.FeedgenResolveResponse, middleware.Responder) {
+	muids := make(pq.Int64Array, 0, len(resolutions))
+	for _, r := range resolutions {
+		for _, muid := range r.muids {
+			muids = append(muids, int64(muid))
+		}
+	}
+	metadata, err := s.mangaStore.FindMangaMetadata(ctx, muids)
+	if err != nil {
+		logger.Errf(ctx, "Failed to find manga metadata err:%+v", err)
+		return nil, lib.NewResponse(ctx, http.StatusBadGateway)
+	}
+	payload := &models.FeedgenResolveResponse{Titles: make([]*models.FeedgenTitleResolution, 0, len(resolutions))}
+	for _, r := range resolutions {
+		tr := &models.FeedgenTitleResolution{
+			Title:      r.title,
+			Entry:      r.entry,
+			Status:     r.status(),
+			Series:     make([]*models.FeedgenSeries, 0, len(r.muids)),
+			Candidates: titleCandidatesPayload(r.candidates),
+		}
+		for _, muid := range r.muids {
+			mm, ok := metadata[muid]
+			if !ok {
+				mm.MUID = muid
+			}
+			tr.Series = append(tr.Series, &models.FeedgenSeries{
+				Muid:         int64(mm.MUID),
+				DisplayTitle: mm.DisplayTitle,
+				URL:          s.source.SeriesURL(mm.MUID),
+				Type:         mm.Type,
+				Status:       mm.Status,
+				Year:         int64(mm.Year),
+				Authors:      mm.Authors,
+				Artists:      mm.Artists,
+				Genres:       mm.Genres,
+			})
+		}
+		payload.Titles = append(payload.Titles, tr)
+	}
+	return payload, nil
+}
+
+// titleCandidatesPayload describes candidates in API responses.
+func titleCandidatesPayload(candidates []db.TitleCandidate) []*models.FeedgenTitleCandidate {
+	payload := make([]*models.FeedgenTitleCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		payload = append(payload, &models.FeedgenTitleCandidate{
+			Muid:         int64(c.MUID),
+			Title:        c.Title,
+			DisplayTitle: c.DisplayTitle,
+			Score:        c.Score,
+		})
+	}
+	return payload
+}
+
+// appendUniqueMUID appends muid to muids unless it's already there.
+func appendUniqueMUID(muids []int, muid int) []int {
+	for _, m := range muids {
+		if m == muid {
+			return muids
+		}
+	}
+	return append(muids, muid)
+}
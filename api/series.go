@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danlock/feedgen/catalog"
+	"github.com/danlock/feedgen/lib"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxScrapedSeries is how many series missing from the db are scraped for a request, since each takes a request to the source
+	maxScrapedSeries = 20
+	// scrapeTimeout is how long a request may spend scraping series missing from the db, after which the rest are reported as unknown
+	scrapeTimeout = 20 * time.Second
+)
+
+// splitSeriesURLs moves the titles that are really links, like a bookmarked series page, over to urls.
+func splitSeriesURLs(titles, urls []string) ([]string, []string) {
+	onlyTitles := make([]string, 0, len(titles))
+	for _, t := range titles {
+		lower := strings.ToLower(strings.TrimSpace(t))
+		if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.Contains(lower, "mangaupdates.com/") {
+			urls = append(urls, t)
+		} else {
+			onlyTitles = append(onlyTitles, t)
+		}
+	}
+	return onlyTitles, urls
+}
+
+// parseSeriesURLs reads the muids of the series urls link to, returning the urls the source can't identify a series from as unmatched.
+func (s *FgService) parseSeriesURLs(ctx context.Context, urls []string) (muids []int64, unmatched []string) {
+	parser, ok := s.source.(scrape.SeriesURLParser)
+	for _, raw := range urls {
+		link := strings.TrimSpace(raw)
+		if !strings.Contains(link, "://") {
+			link = "https://" + link
+		}
+		if u, err := url.Parse(link); ok && err == nil {
+			muid, err := parser.ParseSeriesURL(ctx, u)
+			if err == nil {
+				muids = append(muids, int64(muid))
+				continue
+			}
+			if errors.Cause(err) != scrape.ErrNotSeriesURL {
+				logger.Warnf(ctx, "Failed looking up the series of %s err:%+v", link, err)
+			}
+		}
+		unmatched = append(unmatched, raw)
+	}
+	return muids, unmatched
+}
+
+// findOrScrapeManga returns the muid each of muids is now after merges, or 0 and listed in unknown if it isn't found, scraping a few missing ones.
+func (s *FgService) findOrScrapeManga(ctx context.Context, muids []int64) (current []int, unknown []int64, resp middleware.Responder) {
+	if len(muids) == 0 {
+		return nil, nil, nil
+	}
+	resolved, err := s.mangaStore.ResolveMUIDs(ctx, pq.Int64Array(muids))
+	if err != nil {
+		logger.Errf(ctx, "Failed to resolve muids err:%+v", err)
+		return nil, nil, lib.NewResponse(ctx, http.StatusBadGateway)
+	}
+	scrapeCtx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+	current = make([]int, len(muids))
+	scraped := 0
+	for i, muid := range muids {
+		if now, found := resolved[int(muid)]; found {
+			current[i] = now
+		} else if muid >= 1 && scraped < maxScrapedSeries && scrapeCtx.Err() == nil {
+			scraped++
+			if current[i], err = catalog.ScrapeAndSave(scrapeCtx, s.mangaStore, s.source, int(muid)); err != nil {
+				logger.Warnf(ctx, "Failed to scrape manga %d, reporting it as unknown err:%+v", muid, err)
+				current[i] = 0
+			}
+		}
+		if current[i] == 0 {
+			unknown = append(unknown, muid)
+		}
+	}
+	if scraped > 0 {
+		logger.Infof(ctx, "Scraped %d requested manga missing from the db", scraped)
+	}
+	return current, unknown, nil
+}
@@ -0,0 +1,95 @@
+// Package catalog keeps the manga in the db in step with a Source, saving scraped series and reconciling the ones deleted or merged since.
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/danlock/feedgen/cover"
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/pkg/errors"
+)
+
+// ScrapeAndSave scrapes the manga with the given muid from src and saves it, reconciling series that were deleted or merged.
+// It returns the muid the series is now, which is the series it was merged into if it was, or 0 if it was deleted.
+func ScrapeAndSave(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source, muid int) (int, error) {
+	before := time.Now()
+	manga, err := src.GetMangaInfo(ctx, muid)
+	if errors.Cause(err) == scrape.ErrInvalidMUID || scrape.MergedInto(err) > 0 {
+		return ReconcileGoneSeries(ctx, mangaStore, src, muid, err)
+	} else if err != nil {
+		logger.Errf(ctx, "Failed to query %s for muid %d err: %+v", src.Name(), muid, err)
+		return 0, err
+	}
+	logger.Dbgf(ctx, "Scraped manga %d in %s", muid, time.Since(before).String())
+	return muid, Save(ctx, mangaStore, manga)
+}
+
+// ReconcileGoneSeries updates the db after scraping muid failed with scrapeErr because the series was deleted or merged,
+// returning the muid the series is now like ScrapeAndSave.
+// Deleted series are retired, while merged ones have the series they were merged into scraped and everything moved over to it.
+// Any other scrapeErr is returned unchanged.
+func ReconcileGoneSeries(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source, muid int, scrapeErr error) (int, error) {
+	if errors.Cause(scrapeErr) == scrape.ErrInvalidMUID {
+		return 0, mangaStore.RetireManga(ctx, muid)
+	}
+	target := scrape.MergedInto(scrapeErr)
+	if target == 0 {
+		return 0, scrapeErr
+	}
+	for i := 0; i < scrape.MaxMergeChain; i++ {
+		manga, err := src.GetMangaInfo(ctx, target)
+		if next := scrape.MergedInto(err); next > 0 {
+			target = next
+			continue
+		} else if errors.Cause(err) == scrape.ErrInvalidMUID {
+			logger.Warnf(ctx, "muid %d was merged into %d, which doesn't exist", muid, target)
+			return 0, mangaStore.RetireManga(ctx, muid)
+		} else if err != nil {
+			return 0, err
+		}
+		if err := Save(ctx, mangaStore, manga); err != nil {
+			return 0, err
+		}
+		logger.Infof(ctx, "muid %d was merged into %d, moving it over", muid, target)
+		return target, mangaStore.MergeManga(ctx, muid, target)
+	}
+	return 0, errors.Errorf("muid %d was merged more than %d times", muid, scrape.MaxMergeChain)
+}
+
+// Save upserts a freshly scraped manga and caches its cover.
+func Save(ctx context.Context, mangaStore db.MangaStorer, manga scrape.MangaInfo) error {
+	if err := mangaStore.UpsertManga(ctx, []scrape.MangaInfo{manga}); err != nil {
+		logger.Errf(ctx, "Failed to upserting manga: err %+v", err)
+		return err
+	}
+	cacheCover(ctx, mangaStore, manga)
+	return nil
+}
+
+// cacheCover downloads the cover of manga and stores it with its thumbnail, unless that cover is already cached.
+// Failing to cache a cover isn't fatal, feeds go without it until the manga is scraped again.
+func cacheCover(ctx context.Context, mangaStore db.MangaStorer, manga scrape.MangaInfo) {
+	if manga.CoverURL == "" {
+		return
+	}
+	cachedURL, err := mangaStore.GetCoverSourceURL(ctx, manga.MUID)
+	if err != nil || cachedURL == manga.CoverURL {
+		return
+	}
+	data, contentType, err := scrape.DownloadCover(ctx, manga.CoverURL)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to download cover for muid %d from %s err: %+v", manga.MUID, manga.CoverURL, err)
+		return
+	}
+	img, err := cover.New(data, contentType)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to create thumbnail for muid %d from %s err: %+v", manga.MUID, manga.CoverURL, err)
+		return
+	}
+	if err := mangaStore.UpsertCover(ctx, manga.MUID, manga.CoverURL, img); err != nil {
+		logger.Errf(ctx, "Failed to save cover for muid %d err: %+v", manga.MUID, err)
+	}
+}
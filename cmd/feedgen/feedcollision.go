@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/lib/logger"
+)
+
+// maxMUIDDigits is the most digits a muid is split into when looking for collisions
+const maxMUIDDigits = 18
+
+// findFeedCollisions records every feed hashed the legacy way whose muids, concatenated, also spell out another set of manga in the db.
+// Both sets had the same hash, so whoever asked for the second set may have been given the first one's feed.
+func findFeedCollisions(ctx context.Context, mangaStore db.MangaStorer) error {
+	muids, err := mangaStore.FindMUIDs(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[int64]bool, len(muids))
+	for _, muid := range muids {
+		known[muid] = true
+	}
+	checked, collisions := 0, 0
+	afterHash := ""
+	for {
+		feeds, err := mangaStore.FindLegacyFeeds(ctx, afterHash, 500)
+		if err != nil {
+			return err
+		}
+		if len(feeds) == 0 {
+			break
+		}
+		for _, f := range feeds {
+			colliding := collidingMUIDs(f.MUIDs, known)
+			if colliding == nil {
+				continue
+			}
+			logger.Warnf(ctx, "Feed %s for %v has the same hash as %v", f.Hash, []int64(f.MUIDs), colliding)
+			if err := mangaStore.UpsertFeedCollision(ctx, f.Hash, f.MUIDs, colliding); err != nil {
+				return err
+			}
+			collisions++
+		}
+		checked += len(feeds)
+		afterHash = feeds[len(feeds)-1].Hash
+	}
+	logger.Infof(ctx, "Found %d collisions among %d legacy feeds", collisions, checked)
+	return nil
+}
+
+// collidingMUIDs returns another sorted set of known muids whose digits concatenate to the same string as muids, or nil if there's none.
+func collidingMUIDs(muids []int64, known map[int64]bool) []int64 {
+	parts := make([]string, len(muids))
+	for i, muid := range muids {
+		parts[i] = strconv.FormatInt(muid, 10)
+	}
+	digits := strings.Join(parts, "")
+	// originalNext maps where each of the original muids starts to where it ends, so the original split can be told apart from the rest
+	originalNext := make(map[int]int, len(parts))
+	start := 0
+	for _, p := range parts {
+		originalNext[start] = start + len(p)
+		start += len(p)
+	}
+
+	type state struct {
+		pos        int
+		last       int64
+		onOriginal bool
+	}
+	deadEnds := make(map[state]bool)
+	split := make([]int64, 0, len(muids))
+	var search func(s state) bool
+	search = func(s state) bool {
+		if s.pos == len(digits) {
+			return !s.onOriginal
+		}
+		if deadEnds[s] || digits[s.pos] == '0' {
+			return false
+		}
+		for n := 1; n <= maxMUIDDigits && s.pos+n <= len(digits); n++ {
+			muid, err := strconv.ParseInt(digits[s.pos:s.pos+n], 10, 64)
+			if err != nil || muid < s.last || !known[muid] {
+				continue
+			}
+			split = append(split, muid)
+			next := state{pos: s.pos + n, last: muid, onOriginal: s.onOriginal && originalNext[s.pos] == s.pos+n}
+			if search(next) {
+				return true
+			}
+			split = split[:len(split)-1]
+		}
+		deadEnds[s] = true
+		return false
+	}
+	if !search(state{onOriginal: true}) {
+		return nil
+	}
+	return split
+}
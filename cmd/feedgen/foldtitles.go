@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/lib/logger"
+)
+
+// foldTitles folds and indexes the trigrams of every title stored before mangatitle.folded_title and mangatitletrigram existed,
+// so they can be matched loosely and suggested as candidates.
+func foldTitles(ctx context.Context, mangaStore db.MangaStorer) error {
+	total := 0
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Infof(ctx, "Context closed, stopping after folding %d titles", total)
+			return ctx.Err()
+		default:
+		}
+		folded, err := mangaStore.FoldMissingTitles(ctx, 1000)
+		if err != nil {
+			return err
+		}
+		if folded == 0 {
+			break
+		}
+		total += folded
+		logger.Dbgf(ctx, "Folded %d titles so far", total)
+	}
+	logger.Infof(ctx, "Folded %d titles", total)
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/pkg/errors"
+)
+
+// scrapeRunHistory is how many of a source's previous polls each poll is checked against
+const scrapeRunHistory = 50
+
+// scrapeAlerter raises an alarm once a source's polls have looked anomalous alertAfter times in a row,
+// and again every alertAfter polls after that until they look healthy.
+type scrapeAlerter struct {
+	alertAfter int
+	// webhookURL receives a JSON POST for every alarm. Alarms are only logged if it's empty.
+	webhookURL string
+	client     *http.Client
+}
+
+func newScrapeAlerter(alertAfter int, webhookURL string) *scrapeAlerter {
+	if alertAfter < 1 {
+		alertAfter = 1
+	}
+	return &scrapeAlerter{alertAfter: alertAfter, webhookURL: webhookURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// scrapeAlert is the body POSTed to the webhook. Text makes it readable by chat webhooks such as Slack's or Discord's.
+type scrapeAlert struct {
+	Text        string           `json:"text"`
+	Source      string           `json:"source"`
+	Consecutive int              `json:"consecutive"`
+	Run         scrape.ScrapeRun `json:"run"`
+}
+
+// recordScrapeRun checks run against the previous polls of its source, records it in scrape_runs and raises an alarm if need be.
+func recordScrapeRun(ctx context.Context, mangaStore db.MangaStorer, alerter *scrapeAlerter, run scrape.ScrapeRun) {
+	history, err := mangaStore.FindScrapeRuns(ctx, run.Source, scrapeRunHistory)
+	if err != nil {
+		logger.Errf(ctx, "Failed to find previous scrape runs err: %+v", err)
+	}
+	run.Anomalies = scrape.CheckScrapeRun(run, history)
+	if run.Anomalous() {
+		logger.Warnf(ctx, "%s poll looks anomalous: %s", run.Source, strings.Join(run.Anomalies, ", "))
+	}
+	if err := mangaStore.InsertScrapeRun(ctx, run); err != nil {
+		logger.Errf(ctx, "Failed to record scrape run err: %+v", err)
+	}
+
+	consecutive := 0
+	for _, r := range append([]scrape.ScrapeRun{run}, history...) {
+		if !r.Anomalous() {
+			break
+		}
+		consecutive++
+	}
+	if consecutive == 0 || consecutive%alerter.alertAfter != 0 {
+		return
+	}
+	alert := scrapeAlert{
+		Text:        fmt.Sprintf("feedgen: the last %d polls of %s look anomalous, its parser may need fixing: %s", consecutive, run.Source, strings.Join(run.Anomalies, ", ")),
+		Source:      run.Source,
+		Consecutive: consecutive,
+		Run:         run,
+	}
+	logger.Errf(ctx, "%s", alert.Text)
+	if err := alerter.send(ctx, alert); err != nil {
+		logger.Errf(ctx, "Failed to send scrape alert err: %+v", err)
+	}
+}
+
+// send POSTs alert to the webhook, if there is one.
+func (a *scrapeAlerter) send(ctx context.Context, alert scrapeAlert) error {
+	if a.webhookURL == "" {
+		return nil
+	}
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return errors.Wrap(err, "Failed encoding alert")
+	}
+	req, err := http.NewRequest(http.MethodPost, a.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "Failed creating request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Failed sending alert")
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("Alert webhook returned status %s", resp.Status)
+	}
+	return nil
+}
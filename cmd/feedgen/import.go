@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/danlock/feedgen/api"
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/readinglist"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/pkg/errors"
+)
+
+// importReadingList creates a feed of the manga on the reading list exported to path, logging its URL and the entries left out of it.
+// u is the URL the API is served on, which the feed URL is on.
+func importReadingList(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source, u *url.URL, path string, format readinglist.Format) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	feed, err := api.NewFeedSrvc(u, mangaStore, src).ImportReadingList(ctx, data, format, db.NewFeed{})
+	if err != nil {
+		return err
+	}
+	for _, t := range feed.Unresolved {
+		logger.Warnf(ctx, "No manga found for %s %q", t.Entry, t.Title)
+	}
+	for _, t := range feed.Ambiguous {
+		logger.Warnf(ctx, "%s %q matches %d manga, pick one with the API", t.Entry, t.Title, len(t.Series))
+	}
+	if feed.URL == "" {
+		return errors.New("No entry on the reading list matched a manga")
+	}
+	logger.Infof(ctx, "Imported %d manga into %s", len(feed.Muids), feed.URL)
+	return nil
+}
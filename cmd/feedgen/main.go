@@ -19,11 +19,13 @@ import (
 	"time"
 
 	"github.com/danlock/feedgen/api"
+	"github.com/danlock/feedgen/catalog"
 	"github.com/danlock/feedgen/db"
 	"github.com/danlock/feedgen/gen/restapi"
 	"github.com/danlock/feedgen/gen/restapi/operations"
 	"github.com/danlock/feedgen/lib"
 	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/readinglist"
 	"github.com/danlock/feedgen/scrape"
 	loads "github.com/go-openapi/loads"
 	openruntime "github.com/go-openapi/runtime"
@@ -38,11 +40,23 @@ var (
 	buildTag  = "NO TAG"
 )
 
+// savesSourceIDs are the commands that save the ids of FG_SOURCE in the db
+var savesSourceIDs = map[string]bool{"poll": true, "backfill": true, "populate-db": true, "refresh": true, "reparse": true}
+
 func helpAndQuit() {
 	fmt.Fprintf(flag.CommandLine.Output(), `
 Usage of %s:
-	poll :	Polls sites for updates with the given frequency in go time.Duration format
-	populate-db:	Scrapes the given range of ids from MangaUpdates
+	poll :	Polls the FG_SOURCE source for updates with the given frequency in go time.Duration format
+	populate-db:	Scrapes the given range of ids from MangaUpdates, resuming where it last stopped for that range
+	refresh:	Re-scrapes manga last scraped longer ago than the given go time.Duration, checking for stale manga every hour
+	backfill:	Scrapes the release archive for each day in the given range of dates in YYYY-MM-DD format
+	unresolved:	Lists the titles of releases whose series couldn't be found, waiting to be mapped with map-release
+	map-release:	Maps the given release title to the given manga id, saving every release queued under it
+	import:	Creates a feed of the manga on the given MyAnimeList, AniList or Kitsu reading list export, optionally given its format (mal, anilist or kitsu) and the API URL the feed is served on
+	feed-collisions:	Records the feeds from before feed hashes were versioned whose hash another set of manga in the db also had, so the API refuses them and links to the feed of each set instead
+	fold-titles:	Folds the titles saved before title search matched loosely and indexes their trigrams, so they're matched and suggested as candidates
+	parse-releases:	Fills in the volume and chapters of releases stored before the release parser last changed by parsing their release text again
+	reparse:	Parses the pages snapshotted in the given range of dates in YYYY-MM-DD format again, overwriting the releases already saved, without refetching
 	api:	serves an API on the URL provided (defaulting to http://localhost:8080) with RSS, Atom or JSON Feed endpoints.
 `, os.Args[0])
 	flag.PrintDefaults()
@@ -58,10 +72,14 @@ func main() {
 	var (
 		dotenvLocation string
 		help           bool
+		workers        int
+		delay          time.Duration
 	)
 	flag.Usage = helpAndQuit
 	flag.StringVar(&dotenvLocation, "e", "./ops/.env", "Location of .env file with environment variables in KEY=VALUE format")
 	flag.BoolVar(&help, "h", false, "Show help")
+	flag.IntVar(&workers, "workers", 4, "Number of manga populate-db scrapes at once")
+	flag.DurationVar(&delay, "delay", 500*time.Millisecond, "Minimum time between populate-db starting each request, shared by every worker")
 	flag.Parse()
 
 	if help {
@@ -82,6 +100,46 @@ func main() {
 		}
 	}
 	mangaStore := db.NewMangaStore(crdb)
+	// Keep every page scraped so it can be reparsed after MangaUpdates changes its markup
+	scrape.SetSnapshotStore(mangaStore)
+	// FG_USER_AGENT and FG_REQUESTS_PER_SECOND configure how politely every source is scraped
+	fetcherOpts := scrape.FetcherOptions{UserAgent: os.Getenv("FG_USER_AGENT")}
+	if rps := os.Getenv("FG_REQUESTS_PER_SECOND"); rps != "" {
+		if fetcherOpts.RequestsPerSecond, err = strconv.ParseFloat(rps, 64); err != nil {
+			logger.Errf(ctx, "FG_REQUESTS_PER_SECOND %s is not a number", rps)
+			os.Exit(1)
+		}
+	}
+	scrape.SetDefaultFetcher(scrape.NewFetcher(fetcherOpts))
+	// FG_MU_API_URL points the MangaUpdates JSON API source at a different host, such as a local mirror
+	if muAPIURL := os.Getenv("FG_MU_API_URL"); muAPIURL != "" {
+		scrape.RegisterSource(scrape.NewMangaUpdatesAPI(muAPIURL, nil))
+	}
+	// FG_RESOLVE_BY_SEARCH searches the source for the series of releases whose title doesn't match any manga in the db
+	resolveBySearch, _ = strconv.ParseBool(os.Getenv("FG_RESOLVE_BY_SEARCH"))
+	// FG_SOURCE selects the Source used for scraping series info, defaulting to MangaUpdates.
+	// Set it to mangaupdates-api to use the JSON API instead of scraping HTML.
+	sourceName := os.Getenv("FG_SOURCE")
+	if sourceName == "" {
+		sourceName = scrape.MangaUpdatesSourceName
+	}
+	source, err := scrape.GetSource(sourceName)
+	if err != nil {
+		logger.Errf(ctx, "FG_SOURCE %s is not a registered source", sourceName)
+		os.Exit(1)
+	}
+	// Sources can number series differently, so every muid in the db must come from the same one.
+	// Only the commands saving the source's ids claim the db for it, the rest just check they aren't reading another source's.
+	getOwner := mangaStore.GetMUIDSource
+	if savesSourceIDs[flag.Arg(0)] {
+		getOwner = func(ctx context.Context) (string, error) { return mangaStore.ClaimMUIDSource(ctx, source.Name()) }
+	}
+	if owner, err := getOwner(ctx); err != nil {
+		os.Exit(1)
+	} else if owner != "" && owner != source.Name() {
+		logger.Errf(ctx, "FG_SOURCE is %s but the db holds %s ids, which %s would mix up with its own. Set FG_SOURCE to %s or use another db", source.Name(), owner, source.Name(), owner)
+		os.Exit(1)
+	}
 
 	// Setup interrupt handler. This optional step configures the process so
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
@@ -122,7 +180,16 @@ func main() {
 			logger.Errf(ctx, "poll takes in 1 arg, the duration between each polling attempt. 6h is recommended.")
 			os.Exit(1)
 		}
-		if handlePoll(ctx, mangaStore, freq) != nil {
+		// FG_ALERT_AFTER is how many anomalous polls in a row raise an alarm, sent to FG_ALERT_WEBHOOK if it's set
+		alertAfter := 3
+		if after := os.Getenv("FG_ALERT_AFTER"); after != "" {
+			if alertAfter, err = strconv.Atoi(after); err != nil {
+				logger.Errf(ctx, "FG_ALERT_AFTER %s is not a number", after)
+				os.Exit(1)
+			}
+		}
+		alerter := newScrapeAlerter(alertAfter, os.Getenv("FG_ALERT_WEBHOOK"))
+		if pollSource(logger.WithLogCtxf(ctx, "source: %s", source.Name()), mangaStore, alerter, source, freq) != nil {
 			os.Exit(1)
 		}
 	case "populate-db":
@@ -132,11 +199,92 @@ func main() {
 			logger.Errf(ctx, "populate-db takes in two args, the start and end range of the MangaUpdate manga ids to scrape from.")
 			os.Exit(1)
 		}
-		muids := make([]int, 0, end-start)
-		for i := start; i <= end; i++ {
-			muids = append(muids, i)
+		if _, ok := source.(scrape.SequentialSource); !ok {
+			logger.Errf(ctx, "%s does not number its series in order, so it can't be populated by id range", source.Name())
+			os.Exit(1)
+		}
+		if populateDB(ctx, mangaStore, source, start, end, workers, delay) != nil {
+			os.Exit(1)
+		}
+	case "backfill":
+		start, serr := time.Parse(dateFormat, flag.Arg(1))
+		end, eerr := time.Parse(dateFormat, flag.Arg(2))
+		if serr != nil || eerr != nil || start.After(end) {
+			logger.Errf(ctx, "backfill takes in two args, the start and end dates in YYYY-MM-DD format of the releases to scrape.")
+			os.Exit(1)
+		}
+		archiveSource, ok := source.(scrape.ArchiveSource)
+		if !ok {
+			logger.Errf(ctx, "%s does not support backfilling releases", source.Name())
+			os.Exit(1)
+		}
+		if backfill(ctx, mangaStore, archiveSource, start, end) != nil {
+			os.Exit(1)
+		}
+	case "reparse":
+		start, serr := time.Parse(dateFormat, flag.Arg(1))
+		end, eerr := time.Parse(dateFormat, flag.Arg(2))
+		if serr != nil || eerr != nil || start.After(end) {
+			logger.Errf(ctx, "reparse takes in two args, the start and end dates in YYYY-MM-DD format of the snapshots to parse again.")
+			os.Exit(1)
+		}
+		parser, ok := source.(scrape.SnapshotParser)
+		if !ok {
+			logger.Errf(ctx, "%s does not support reparsing snapshots", source.Name())
+			os.Exit(1)
+		}
+		// Include every snapshot taken on the end date
+		if reparse(ctx, mangaStore, parser, start, end.AddDate(0, 0, 1)) != nil {
+			os.Exit(1)
+		}
+	case "unresolved":
+		if listUnresolved(ctx, mangaStore, source) != nil {
+			os.Exit(1)
+		}
+	case "map-release":
+		muid, err := strconv.Atoi(flag.Arg(2))
+		if flag.Arg(1) == "" || err != nil || muid < 1 {
+			logger.Errf(ctx, "map-release takes in two args, the release title as listed by unresolved and the manga id it belongs to.")
+			os.Exit(1)
+		}
+		if mapReleaseTitle(ctx, mangaStore, source, flag.Arg(1), muid) != nil {
+			os.Exit(1)
+		}
+	case "parse-releases":
+		if parseReleases(ctx, mangaStore) != nil {
+			os.Exit(1)
+		}
+	case "fold-titles":
+		if foldTitles(ctx, mangaStore) != nil {
+			os.Exit(1)
+		}
+	case "feed-collisions":
+		if findFeedCollisions(ctx, mangaStore) != nil {
+			os.Exit(1)
+		}
+	case "refresh":
+		staleness, err := time.ParseDuration(flag.Arg(1))
+		if err != nil || staleness <= 0 {
+			logger.Errf(ctx, "refresh takes in 1 arg, how long ago a manga must have been scraped before it's scraped again. 720h is recommended.")
+			os.Exit(1)
+		}
+		if refreshManga(ctx, mangaStore, source, staleness, workers, delay) != nil {
+			os.Exit(1)
+		}
+	case "import":
+		format := readinglist.Format(flag.Arg(2))
+		if flag.Arg(1) == "" || (format != "" && format != readinglist.MyAnimeList && format != readinglist.AniList && format != readinglist.Kitsu) {
+			logger.Errf(ctx, "import takes in 1 to 3 args, the reading list export file, its format (mal, anilist or kitsu, worked out from the file if missing) and the API URL (defaulting to http://localhost:8080).")
+			os.Exit(1)
+		}
+		u, err := url.Parse(flag.Arg(3))
+		if flag.Arg(3) == "" || err != nil {
+			if u, err = url.Parse("http://localhost:8080"); err != nil {
+				panic("defaultURL is invalid URL")
+			}
 		}
-		if scrapeAndSaveManga(ctx, mangaStore, muids) != nil {
+		if err := importReadingList(ctx, mangaStore, source, u, flag.Arg(1), format); err != nil {
+			logger.Errf(ctx, "Failed to import %s err:%+v", flag.Arg(1), err)
 			os.Exit(1)
 		}
 	case "api":
@@ -148,69 +296,52 @@ func main() {
 				panic("defaultURL is invalid URL")
 			}
 		}
-		handleHTTPServer(ctx, u, apiModels{mangaStore: mangaStore})
+		handleHTTPServer(ctx, u, apiModels{mangaStore: mangaStore, source: source})
 	default:
-		logger.Infof(ctx, "Available commands are poll,api,populate-db")
+		logger.Infof(ctx, "Available commands are poll,api,populate-db,refresh,backfill,reparse,unresolved,map-release,feed-collisions,fold-titles,parse-releases,import")
 		helpAndQuit()
 	}
 }
 
-func scrapeAndSaveManga(ctx context.Context, mangaStore db.MangaStorer, muids []int) error {
+func scrapeAndSaveManga(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source, muids []int) error {
 	for _, i := range muids {
 		select {
 		case <-ctx.Done():
-			logger.Infof(ctx, "Context closed, shutting down populate-db...")
+			logger.Infof(ctx, "Context closed, shutting down scraping manga...")
 			return ctx.Err()
 		default:
 		}
-		before := time.Now()
-		manga, err := scrape.GetAndParseMUMangaPage(ctx, i)
-		if err == scrape.ErrInvalidMUID {
-			continue
-		} else if err != nil {
-			logger.Errf(ctx, "Failed to query Mangaupdates for muid %d err: %+v", i, err)
-			return err
-		}
-		logger.Dbgf(ctx, "Scraped manga %d in %s", i, time.Since(before).String())
-		if err := mangaStore.UpsertManga(ctx, []scrape.MangaInfo{manga}); err != nil {
-			logger.Errf(ctx, "Failed to upserting manga: err %+v", err)
+		if _, err := catalog.ScrapeAndSave(ctx, mangaStore, src, i); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func handlePoll(ctx context.Context, mangaStore db.MangaStorer, freq time.Duration) error {
-	// Scrape new releases out of MU
-	releaseChan := scrape.PollMUForReleases(ctx, freq)
+// dateFormat is the format of dates accepted on the command line
+const dateFormat = "2006-01-02"
+
+// recentReleasesWindow is how far back a Source's recent releases reach. Polls further apart than this leave a gap that needs backfilling.
+const recentReleasesWindow = 48 * time.Hour
+
+// pollSource polls src for releases and saves them until ctx is done.
+func pollSource(ctx context.Context, mangaStore db.MangaStorer, alerter *scrapeAlerter, src scrape.Source, freq time.Duration) error {
+	// Scrape new releases out of the source, keeping an eye on whether they still look right
+	report := func(run scrape.ScrapeRun) { recordScrapeRun(ctx, mangaStore, alerter, run) }
+	releaseChan := scrape.PollForReleases(ctx, src, freq, report)
 	for {
 		select {
 		case releases, running := <-releaseChan:
 			if !running {
 				return nil
 			}
-			// Each new batch of releases may include new manga not in the db, filter them out
-			newReleases, err := mangaStore.FilterOutReleasesWithoutMangaInDB(ctx, releases)
-			if err != nil {
-				logger.Errf(ctx, "Failed to filter out new manga releases!")
-			}
-			// Scrape the info page for each new manga found and place them in db
-			newRelLen := len(newReleases)
-			if newRelLen > 0 {
-				logger.Infof(ctx, "Found %d new titles, scraping their pages...", newRelLen)
-				start := time.Now()
-				muids := make([]int, 0, newRelLen)
-				for _, r := range newReleases {
-					muids = append(muids, r.MUID)
-				}
-				if err := scrapeAndSaveManga(ctx, mangaStore, muids); err != nil {
-					logger.Errf(ctx, "Failed to scrape and save manga for new releases with ids %+v err: %+v", muids, err)
-				}
-				logger.Dbgf(ctx, "Finished scraping and saving %d new titles in %s", newRelLen, time.Since(start).String())
+			pollStart := time.Now()
+			backfillGap(ctx, mangaStore, src, pollStart)
+			if err := saveReleases(ctx, mangaStore, src, releases); err != nil {
+				continue
 			}
-			// Finally upsert releases in DB in case there are duplicates
-			if err := mangaStore.UpsertRelease(ctx, releases); err != nil {
-				logger.Errf(ctx, "Failed to upsert manga releases err:%+v", err)
+			if err := mangaStore.SetLastPoll(ctx, src.Name(), pollStart); err != nil {
+				logger.Errf(ctx, "Failed to record successful poll err:%+v", err)
 			}
 		case <-ctx.Done():
 			logger.Infof(ctx, "exiting (%v)", ctx.Err())
@@ -219,8 +350,83 @@ func handlePoll(ctx context.Context, mangaStore db.MangaStorer, freq time.Durati
 	}
 }
 
+// backfillGap backfills the releases missed since the last successful poll, if it is older than the source's recent releases.
+func backfillGap(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source, now time.Time) {
+	archiveSource, ok := src.(scrape.ArchiveSource)
+	if !ok {
+		return
+	}
+	lastPoll, err := mangaStore.GetLastPoll(ctx, src.Name())
+	if err != nil || lastPoll.IsZero() || now.Sub(lastPoll) <= recentReleasesWindow {
+		return
+	}
+	end := now.Add(-recentReleasesWindow)
+	logger.Warnf(ctx, "Last successful poll was at %s, backfilling releases until %s", lastPoll, end)
+	if err := backfill(ctx, mangaStore, archiveSource, lastPoll, end); err != nil {
+		logger.Errf(ctx, "Failed to backfill releases since %s err:%+v", lastPoll, err)
+	}
+}
+
+// backfill saves the releases from every day between start and end inclusive.
+func backfill(ctx context.Context, mangaStore db.MangaStorer, src scrape.ArchiveSource, start, end time.Time) error {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		select {
+		case <-ctx.Done():
+			logger.Infof(ctx, "Context closed, shutting down backfill...")
+			return ctx.Err()
+		default:
+		}
+		before := time.Now()
+		releases, err := src.QueryReleasesOn(ctx, day)
+		if err != nil {
+			logger.Errf(ctx, "Failed to query %s releases on %s err: %+v", src.Name(), day.Format(dateFormat), err)
+			return err
+		}
+		logger.Dbgf(ctx, "Scraped %d releases from %s in %s", len(releases), day.Format(dateFormat), time.Since(before).String())
+		if err := saveReleases(ctx, mangaStore, src, releases); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveReleases upserts releases, resolving the series of those that don't link to one and scraping the info of any manga not yet in the db first.
+func saveReleases(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source, releases []scrape.MangaRelease) error {
+	if len(releases) == 0 {
+		return nil
+	}
+	releases = resolveReleases(ctx, mangaStore, src, releases)
+	// Each new batch of releases may include new manga not in the db, filter them out
+	newReleases, err := mangaStore.FilterOutReleasesWithoutMangaInDB(ctx, releases)
+	if err != nil {
+		logger.Errf(ctx, "Failed to filter out new manga releases!")
+	}
+	// Scrape the info page for each new manga found and place them in db
+	newRelLen := len(newReleases)
+	if newRelLen > 0 {
+		logger.Infof(ctx, "Found %d new titles, scraping their pages...", newRelLen)
+		start := time.Now()
+		muids := make([]int, 0, newRelLen)
+		for _, r := range newReleases {
+			muids = append(muids, r.MUID)
+		}
+		if err := scrapeAndSaveManga(ctx, mangaStore, src, muids); err != nil {
+			logger.Errf(ctx, "Failed to scrape and save manga for new releases with ids %+v err: %+v", muids, err)
+		}
+		logger.Dbgf(ctx, "Finished scraping and saving %d new titles in %s", newRelLen, time.Since(start).String())
+	}
+	// Finally upsert releases in DB in case there are duplicates
+	if err := mangaStore.UpsertRelease(ctx, releases); err != nil {
+		logger.Errf(ctx, "Failed to upsert manga releases err:%+v", err)
+		return err
+	}
+	return nil
+}
+
 type apiModels struct {
 	mangaStore db.MangaStorer
+	source     scrape.Source
 }
 
 func handleHTTPServer(ctx context.Context, u *url.URL, models apiModels) {
@@ -236,7 +442,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL, models apiModels) {
 	}
 	operationsAPI.JSONConsumer = openruntime.JSONConsumer()
 	operationsAPI.XMLConsumer = openruntime.XMLConsumer()
-	operationsAPI.Logger = func(msg string, vals ...interface{}) { logger.InfofWithCallDepth(ctx, 5, msg, vals) }
+	operationsAPI.Logger = func(msg string, vals ...interface{}) { logger.InfofWithCallDepth(ctx, 5, msg, vals...) }
 
 	// lazyEncoder just check if the result is a string before encoding. If it is, it assumes it has already been encoded and sends it directly.
 	type encoder interface{ Encode(interface{}) error }
@@ -255,10 +461,16 @@ func handleHTTPServer(ctx context.Context, u *url.URL, models apiModels) {
 		enc.SetEscapeHTML(false)
 		return lazyEncoder(enc, writer, data)
 	})
-	fs := api.NewFeedSrvc(u, models.mangaStore)
+	fs := api.NewFeedSrvc(u, models.mangaStore, models.source)
 	operationsAPI.FeedgenMangaHandler = operations.FeedgenMangaHandlerFunc(fs.Manga)
+	operationsAPI.FeedgenImportMangaHandler = operations.FeedgenImportMangaHandlerFunc(fs.ImportManga)
 	operationsAPI.FeedgenViewMangaHandler = operations.FeedgenViewMangaHandlerFunc(fs.ViewManga)
 	operationsAPI.FeedgenViewMangaTitlesHandler = operations.FeedgenViewMangaTitlesHandlerFunc(fs.ViewMangaTitles)
+	operationsAPI.FeedgenMangaCoverHandler = operations.FeedgenMangaCoverHandlerFunc(fs.MangaCover)
+	operationsAPI.FeedgenResolveMangaHandler = operations.FeedgenResolveMangaHandlerFunc(fs.ResolveManga)
+	operationsAPI.FeedgenUpdateMangaHandler = operations.FeedgenUpdateMangaHandlerFunc(fs.UpdateManga)
+	operationsAPI.FeedgenPatchMangaHandler = operations.FeedgenPatchMangaHandlerFunc(fs.PatchManga)
+	operationsAPI.FeedgenDeleteMangaHandler = operations.FeedgenDeleteMangaHandlerFunc(fs.DeleteManga)
 	operationsAPI.Init()
 
 	server := restapi.NewServer(operationsAPI)
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/lib/logger"
+)
+
+// parseReleases parses the release text of every release stored before the current scrape.ReleaseParserVersion again,
+// filling in the volume and chapter columns of releases stored before they existed or parsed wrong by an older parser.
+func parseReleases(ctx context.Context, mangaStore db.MangaStorer) error {
+	total := 0
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Infof(ctx, "Context closed, stopping after parsing %d releases", total)
+			return ctx.Err()
+		default:
+		}
+		parsed, err := mangaStore.ParseStaleReleases(ctx, 1000)
+		if err != nil {
+			return err
+		}
+		if parsed == 0 {
+			break
+		}
+		total += parsed
+		logger.Dbgf(ctx, "Parsed %d releases so far", total)
+	}
+	logger.Infof(ctx, "Parsed %d releases", total)
+	return nil
+}
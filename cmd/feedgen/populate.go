@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/danlock/feedgen/catalog"
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/pkg/errors"
+)
+
+const (
+	// populateCheckpointEvery is how many muids are scraped between saving populate-db's progress
+	populateCheckpointEvery = 100
+	// populateRetries is how many more times the muids that failed are scraped before giving up on them until the next run
+	populateRetries = 3
+	// populateRetryBackoff is how long to wait before the first retry, growing with each one after
+	populateRetryBackoff = 30 * time.Second
+)
+
+// populateResult is the outcome of scraping a single muid
+type populateResult struct {
+	muid int
+	err  error
+}
+
+// populateDB scrapes and saves every muid from start to end inclusive with the given number of workers,
+// starting a new request at most once every delay across all of them.
+// Progress is checkpointed so an interrupted run picks up where it stopped, after first retrying the muids that previously failed.
+func populateDB(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source, start, end, workers int, delay time.Duration) error {
+	if workers < 1 {
+		workers = 1
+	}
+	if delay <= 0 {
+		delay = time.Millisecond
+	}
+	next, err := mangaStore.GetPopulateCheckpoint(ctx, src.Name(), start, end)
+	if err != nil {
+		return err
+	}
+	previouslyFailed, err := mangaStore.FindPopulateFailures(ctx, src.Name(), start, end)
+	if err != nil {
+		return err
+	}
+	if next > start {
+		logger.Infof(ctx, "Resuming populate-db from muid %d, retrying %d failed muids first", next, len(previouslyFailed))
+	}
+	muids := make([]int, 0, len(previouslyFailed)+end-next+1)
+	// Failures from next on are scraped again with the rest of the range anyway
+	for _, muid := range previouslyFailed {
+		if muid < next {
+			muids = append(muids, muid)
+		}
+	}
+	for i := next; i <= end; i++ {
+		muids = append(muids, i)
+	}
+
+	limiter := time.NewTicker(delay)
+	defer limiter.Stop()
+	scrapeFn := func(ctx context.Context, muid int) error {
+		_, err := catalog.ScrapeAndSave(ctx, mangaStore, src, muid)
+		return err
+	}
+
+	// next only moves past a muid once every muid before it has finished, since workers finish out of order
+	finished := make(map[int]bool)
+	sinceCheckpoint := 0
+	failed := make([]int, 0)
+	scrapeConcurrently(ctx, muids, workers, limiter.C, scrapeFn, func(r populateResult) {
+		if r.err != nil && ctx.Err() != nil {
+			// Interrupted rather than failed, so leave it for the next run
+			return
+		}
+		recordPopulateResult(ctx, mangaStore, src, r)
+		if r.err != nil {
+			failed = append(failed, r.muid)
+		}
+		if r.muid < next {
+			return
+		}
+		finished[r.muid] = true
+		for finished[next] {
+			delete(finished, next)
+			next++
+		}
+		if sinceCheckpoint++; sinceCheckpoint >= populateCheckpointEvery {
+			sinceCheckpoint = 0
+			if err := mangaStore.SetPopulateCheckpoint(ctx, src.Name(), start, end, next); err != nil {
+				logger.Errf(ctx, "Failed to save populate-db progress at muid %d err: %+v", next, err)
+			}
+		}
+	})
+	if err := mangaStore.SetPopulateCheckpoint(context.Background(), src.Name(), start, end, next); err != nil {
+		logger.Errf(ctx, "Failed to save populate-db progress at muid %d err: %+v", next, err)
+	}
+
+	for retry := 1; retry <= populateRetries && len(failed) > 0 && ctx.Err() == nil; retry++ {
+		backoff := time.Duration(retry) * populateRetryBackoff
+		logger.Warnf(ctx, "Failed to scrape %d muids, retrying them in %s", len(failed), backoff)
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		stillFailed := make([]int, 0)
+		scrapeConcurrently(ctx, failed, workers, limiter.C, scrapeFn, func(r populateResult) {
+			if r.err != nil && ctx.Err() != nil {
+				return
+			}
+			recordPopulateResult(ctx, mangaStore, src, r)
+			if r.err != nil {
+				stillFailed = append(stillFailed, r.muid)
+			}
+		})
+		failed = stillFailed
+	}
+	if ctx.Err() != nil {
+		logger.Infof(ctx, "Context closed, shutting down populate-db at muid %d...", next)
+		return ctx.Err()
+	}
+	if len(failed) > 0 {
+		logger.Errf(ctx, "Gave up on scraping muids %+v, they will be retried the next time populate-db runs over them", failed)
+		return errors.Errorf("Failed to scrape %d muids", len(failed))
+	}
+	return nil
+}
+
+// recordPopulateResult remembers a failure so it can be retried later, or forgets it once muid has been scraped.
+func recordPopulateResult(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source, r populateResult) {
+	var err error
+	if r.err != nil {
+		logger.Errf(ctx, "Failed to scrape muid %d err: %+v", r.muid, r.err)
+		err = mangaStore.UpsertPopulateFailure(ctx, src.Name(), r.muid, r.err)
+	} else {
+		err = mangaStore.DeletePopulateFailure(ctx, src.Name(), r.muid)
+	}
+	if err != nil {
+		logger.Errf(ctx, "Failed to record the result of scraping muid %d err: %+v", r.muid, err)
+	}
+}
+
+// scrapeConcurrently calls scrapeFn on muids with a pool of workers, handing out a muid each time limiter fires.
+// done is called with the result of each muid in the order they finish, always from the calling goroutine.
+func scrapeConcurrently(ctx context.Context, muids []int, workers int, limiter <-chan time.Time, scrapeFn func(ctx context.Context, muid int) error, done func(populateResult)) {
+	jobs := make(chan int)
+	results := make(chan populateResult)
+	go func() {
+		defer close(jobs)
+		for _, muid := range muids {
+			select {
+			case <-ctx.Done():
+				return
+			case <-limiter:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- muid:
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for muid := range jobs {
+				results <- populateResult{muid: muid, err: scrapeFn(ctx, muid)}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	for r := range results {
+		done(r)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/danlock/feedgen/catalog"
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+)
+
+const (
+	// refreshInterval is how often refresh looks for stale manga
+	refreshInterval = time.Hour
+	// refreshBatchSize is the most manga refreshed every refreshInterval
+	refreshBatchSize = 1000
+)
+
+// refreshManga re-scrapes the manga last scraped longer than staleness ago every refreshInterval until ctx is done,
+// with the given number of workers starting a new request at most once every delay.
+func refreshManga(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source, staleness time.Duration, workers int, delay time.Duration) error {
+	if workers < 1 {
+		workers = 1
+	}
+	if delay <= 0 {
+		delay = time.Millisecond
+	}
+	limiter := time.NewTicker(delay)
+	defer limiter.Stop()
+	timer := time.NewTicker(refreshInterval)
+	defer timer.Stop()
+	for {
+		muids, err := mangaStore.FindStaleManga(ctx, time.Now().Add(-staleness), refreshBatchSize)
+		if err != nil {
+			logger.Errf(ctx, "Failed to find stale manga err: %+v", err)
+		} else if len(muids) > 0 {
+			start := time.Now()
+			failed := 0
+			scrapeFn := func(ctx context.Context, muid int) error {
+				_, err := catalog.ScrapeAndSave(ctx, mangaStore, src, muid)
+				return err
+			}
+			scrapeConcurrently(ctx, muids, workers, limiter.C, scrapeFn, func(r populateResult) {
+				if r.err != nil && ctx.Err() == nil {
+					failed++
+					logger.Errf(ctx, "Failed to refresh muid %d err: %+v", r.muid, r.err)
+				}
+			})
+			logger.Infof(ctx, "Refreshed %d stale manga with %d failures in %s", len(muids)-failed, failed, time.Since(start).String())
+		}
+		select {
+		case <-ctx.Done():
+			logger.Infof(ctx, "Context closed, shutting down refresh...")
+			return nil
+		case <-timer.C:
+		}
+	}
+}
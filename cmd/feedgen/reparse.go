@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/pkg/errors"
+)
+
+// reparseBatchSize is how many snapshots reparse loads from the db at once
+const reparseBatchSize = 100
+
+// reparse parses every page src snapshotted between start and end again and saves the results, without fetching anything.
+// Series are reparsed first so the releases of series that were only snapshotted can be saved.
+// Releases already in the db are overwritten with what the parser finds now, so a parser fix repairs history.
+func reparse(ctx context.Context, mangaStore db.MangaStorer, src scrape.SnapshotParser, start, end time.Time) error {
+	var afterID int64
+	seriesCount, failed := 0, 0
+	for {
+		snapshots, err := mangaStore.FindLatestSeriesSnapshots(ctx, src.Name(), start, end, afterID, reparseBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			break
+		}
+		manga := make([]scrape.MangaInfo, 0, len(snapshots))
+		for _, snap := range snapshots {
+			afterID = snap.ID
+			m, err := src.ParseSeriesSnapshot(snap.Snapshot)
+			if err == scrape.ErrInvalidMUID || scrape.MergedInto(err) > 0 {
+				// Deleted and merged series are reconciled the next time they are scraped
+				continue
+			} else if err != nil {
+				failed++
+				logger.Errf(ctx, "Failed to reparse series snapshot %d of muid %d err: %+v", snap.ID, snap.MUID, err)
+				continue
+			}
+			manga = append(manga, m)
+		}
+		if len(manga) > 0 {
+			if err := mangaStore.UpsertManga(ctx, manga); err != nil {
+				return err
+			}
+		}
+		seriesCount += len(manga)
+	}
+	logger.Infof(ctx, "Reparsed %d series", seriesCount)
+
+	afterID = 0
+	releaseCount := 0
+	releaseKinds := []scrape.SnapshotKind{scrape.SnapshotRecentReleases, scrape.SnapshotArchiveReleases}
+	for {
+		snapshots, err := mangaStore.FindSnapshots(ctx, src.Name(), releaseKinds, start, end, afterID, reparseBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			break
+		}
+		releases := make([]scrape.MangaRelease, 0)
+		for _, snap := range snapshots {
+			afterID = snap.ID
+			parsed, err := src.ParseReleasesSnapshot(snap.Snapshot)
+			if err != nil {
+				failed++
+				logger.Errf(ctx, "Failed to reparse release snapshot %d of %s err: %+v", snap.ID, snap.URL, err)
+				continue
+			}
+			releases = append(releases, parsed...)
+		}
+		n, err := saveReparsedReleases(ctx, mangaStore, releases)
+		if err != nil {
+			return err
+		}
+		releaseCount += n
+	}
+	logger.Infof(ctx, "Reparsed %d releases", releaseCount)
+	if failed > 0 {
+		return errors.Errorf("Failed to reparse %d snapshots", failed)
+	}
+	return nil
+}
+
+// saveReparsedReleases upserts the releases of manga already in the db, returning how many there were.
+// Unlike saveReleases it doesn't scrape missing manga, so their releases are left for backfill.
+func saveReparsedReleases(ctx context.Context, mangaStore db.MangaStorer, releases []scrape.MangaRelease) (int, error) {
+	if len(releases) == 0 {
+		return 0, nil
+	}
+	missing, err := mangaStore.FilterOutReleasesWithoutMangaInDB(ctx, releases)
+	if err != nil {
+		return 0, err
+	}
+	missingMUIDs := make(map[int]bool, len(missing))
+	for _, r := range missing {
+		missingMUIDs[r.MUID] = true
+	}
+	known := make([]scrape.MangaRelease, 0, len(releases))
+	for _, r := range releases {
+		if r.MUID > 0 && !missingMUIDs[r.MUID] {
+			known = append(known, r)
+		}
+	}
+	if len(missing) > 0 {
+		logger.Warnf(ctx, "Skipping %d reparsed releases of manga not in the db", len(missing))
+	}
+	if len(known) == 0 {
+		return 0, nil
+	}
+	if err := mangaStore.UpsertReparsedReleases(ctx, known); err != nil {
+		return 0, err
+	}
+	return len(known), nil
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/danlock/feedgen/db"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/pkg/errors"
+)
+
+// maxResolveSearches limits how many titles are searched for in each batch of releases, since searches are slow and count against the rate limit
+const maxResolveSearches = 20
+
+// resolveBySearch makes resolveReleases search the source for titles that don't match any in the db. Set by FG_RESOLVE_BY_SEARCH.
+var resolveBySearch bool
+
+// normalizeReleaseTitle formats a release title the way titles are stored in mangatitle.
+func normalizeReleaseTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// resolveReleases fills in the MUID of releases that didn't link to their series, first from the titles an admin or search mapped before,
+// then from the titles of manga in the db, and finally by searching src if resolveBySearch is set.
+// Titles matching more than one series aren't guessed at. Releases still missing their MUID are queued for an admin and left in the result.
+func resolveReleases(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source, releases []scrape.MangaRelease) []scrape.MangaRelease {
+	out := make([]scrape.MangaRelease, len(releases))
+	copy(out, releases)
+	unlinked := make(map[string][]int)
+	for i, r := range out {
+		if r.MUID > 0 {
+			continue
+		}
+		out[i].Title = normalizeReleaseTitle(r.Title)
+		if out[i].Title != "" {
+			unlinked[out[i].Title] = append(unlinked[out[i].Title], i)
+		}
+	}
+	if len(unlinked) == 0 {
+		return out
+	}
+
+	resolved := make(map[string]int, len(unlinked))
+	remaining := func() []string {
+		titles := make([]string, 0, len(unlinked))
+		for t := range unlinked {
+			if _, ok := resolved[t]; !ok {
+				titles = append(titles, t)
+			}
+		}
+		sort.Strings(titles)
+		return titles
+	}
+	mappings, err := mangaStore.FindReleaseTitleMappings(ctx, src.Name(), remaining())
+	if err != nil {
+		logger.Errf(ctx, "Failed to find release title mappings err: %+v", err)
+	}
+	for t, muid := range mappings {
+		resolved[t] = muid
+	}
+	if titles := remaining(); len(titles) > 0 {
+		manga, err := mangaStore.FindMangaByTitlesIntoMangaTitlesSlice(ctx, titles)
+		if err != nil {
+			logger.Errf(ctx, "Failed to find manga by release titles err: %+v", err)
+		}
+		for t, muid := range uniqueMatches(manga) {
+			resolved[t] = muid
+		}
+	}
+	if searcher, ok := src.(scrape.TitleSearcher); ok && resolveBySearch {
+		for i, t := range remaining() {
+			if i >= maxResolveSearches {
+				break
+			}
+			muid, err := searchForTitle(ctx, searcher, t)
+			if err != nil {
+				logger.Warnf(ctx, "Failed to search %s for %q err: %+v", src.Name(), t, err)
+				continue
+			}
+			if muid == 0 {
+				continue
+			}
+			resolved[t] = muid
+			if err := mangaStore.UpsertReleaseTitleMapping(ctx, src.Name(), t, muid, db.ResolvedBySearch); err != nil {
+				logger.Errf(ctx, "Failed to save the mapping of %q to muid %d err: %+v", t, muid, err)
+			}
+		}
+	}
+
+	queue := make([]scrape.MangaRelease, 0)
+	for t, indexes := range unlinked {
+		for _, i := range indexes {
+			if muid, ok := resolved[t]; ok {
+				out[i].MUID = muid
+			} else {
+				queue = append(queue, out[i])
+			}
+		}
+	}
+	logger.Dbgf(ctx, "Resolved %d of %d release titles missing MUIDs", len(resolved), len(unlinked))
+	if len(queue) > 0 {
+		logger.Warnf(ctx, "Queueing %d releases whose series couldn't be found for an admin to map", len(queue))
+		if err := mangaStore.QueueUnresolvedReleases(ctx, src.Name(), queue); err != nil {
+			logger.Errf(ctx, "Failed to queue unresolved releases err: %+v", err)
+		}
+	}
+	return out
+}
+
+// uniqueMatches returns the muid of each title that only one manga goes by.
+func uniqueMatches(manga []db.MangaTitle) map[string]int {
+	matches := make(map[string]int, len(manga))
+	ambiguous := make(map[string]bool)
+	for _, m := range manga {
+		if muid, ok := matches[m.OriginalTitle]; ok && muid != m.MUID {
+			ambiguous[m.OriginalTitle] = true
+		}
+		matches[m.OriginalTitle] = m.MUID
+	}
+	for t := range ambiguous {
+		delete(matches, t)
+	}
+	return matches
+}
+
+// searchForTitle searches src for the series titled title, returning 0 if none or more than one go by exactly that title.
+func searchForTitle(ctx context.Context, src scrape.TitleSearcher, title string) (int, error) {
+	results, err := src.SearchSeries(ctx, title)
+	if err != nil {
+		return 0, err
+	}
+	manga := make([]db.MangaTitle, 0, len(results))
+	for _, r := range results {
+		if normalizeReleaseTitle(r.Title) == title {
+			manga = append(manga, db.MangaTitle{MUID: r.MUID, OriginalTitle: title})
+		}
+	}
+	return uniqueMatches(manga)[title], nil
+}
+
+// listUnresolved prints the titles waiting to be mapped, most often seen first.
+func listUnresolved(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source) error {
+	unresolved, err := mangaStore.FindUnresolvedReleases(ctx, src.Name(), "")
+	if err != nil {
+		return err
+	}
+	type titleSummary struct {
+		title    string
+		releases int
+		seen     int
+		lastSeen string
+		example  string
+	}
+	byTitle := make(map[string]*titleSummary)
+	summaries := make([]*titleSummary, 0)
+	for _, u := range unresolved {
+		s, ok := byTitle[u.Title]
+		if !ok {
+			s = &titleSummary{title: u.Title, example: u.Release + " by " + u.Translators}
+			byTitle[u.Title] = s
+			summaries = append(summaries, s)
+		}
+		s.releases++
+		s.seen += u.SeenCount
+		if lastSeen := u.LastSeenAt.Format(dateFormat); lastSeen > s.lastSeen {
+			s.lastSeen = lastSeen
+		}
+	}
+	sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].seen > summaries[j].seen })
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TITLE\tRELEASES\tSEEN\tLAST SEEN\tEXAMPLE")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n", s.title, s.releases, s.seen, s.lastSeen, s.example)
+	}
+	return w.Flush()
+}
+
+// mapReleaseTitle maps title to muid for every release from src from now on, and saves the releases queued under it.
+func mapReleaseTitle(ctx context.Context, mangaStore db.MangaStorer, src scrape.Source, title string, muid int) error {
+	title = normalizeReleaseTitle(title)
+	if title == "" {
+		return errors.New("Empty title")
+	}
+	if err := mangaStore.UpsertReleaseTitleMapping(ctx, src.Name(), title, muid, db.ResolvedByAdmin); err != nil {
+		return err
+	}
+	unresolved, err := mangaStore.FindUnresolvedReleases(ctx, src.Name(), title)
+	if err != nil {
+		return err
+	}
+	releases := make([]scrape.MangaRelease, 0, len(unresolved))
+	for _, u := range unresolved {
+		releases = append(releases, u.MangaRelease(muid))
+	}
+	if err := saveReleases(ctx, mangaStore, src, releases); err != nil {
+		return err
+	}
+	logger.Infof(ctx, "Mapped %q to muid %d, saving %d queued releases", title, muid, len(releases))
+	return mangaStore.DeleteUnresolvedReleases(ctx, src.Name(), title)
+}
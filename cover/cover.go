@@ -0,0 +1,105 @@
+package cover
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+	"strings"
+
+	// Register the formats covers are served in with image.Decode
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/danlock/feedgen/lib"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ThumbnailWidth is the width in pixels of generated thumbnails. Their height keeps the aspect ratio of the original.
+	ThumbnailWidth = 200
+	// ThumbnailContentType is the Content-Type of every generated thumbnail
+	ThumbnailContentType = "image/jpeg"
+	thumbnailQuality     = 85
+	// MaxPixels is the most pixels a cover can have, since decoding one takes 4 bytes of memory per pixel
+	// no matter how well it compresses. Real covers are a few megapixels at most.
+	MaxPixels = 16 << 20
+)
+
+const ErrTooManyPixels lib.SentinelError = "Cover image has too many pixels"
+
+// Image is a cover image along with its thumbnail, identified by the hash of the original image.
+type Image struct {
+	Hash        string
+	ContentType string `db:"content_type"`
+	Data        []byte `db:"image"`
+	Thumbnail   []byte
+}
+
+// New decodes data as a cover image and generates its thumbnail, refusing images with more than MaxPixels pixels before decoding them.
+// contentType is only trusted if it names an image, otherwise it's sniffed from data.
+func New(data []byte, contentType string) (Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, errors.Wrap(err, "Failed decoding cover image")
+	}
+	if cfg.Width < 1 || cfg.Height < 1 || int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return Image{}, errors.Wrapf(ErrTooManyPixels, "Cover image is %dx%d", cfg.Width, cfg.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, errors.Wrap(err, "Failed decoding cover image")
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		contentType = http.DetectContentType(data)
+	}
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, Resize(src, ThumbnailWidth), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return Image{}, errors.Wrap(err, "Failed encoding thumbnail")
+	}
+	sum := sha256.Sum256(data)
+	return Image{
+		Hash:        hex.EncodeToString(sum[:]),
+		ContentType: contentType,
+		Data:        data,
+		Thumbnail:   thumb.Bytes(),
+	}, nil
+}
+
+// Resize scales src down to width pixels wide by averaging the pixels that fall into each pixel of the result.
+// Images already narrower than width are returned as is.
+func Resize(src image.Image, width int) image.Image {
+	b := src.Bounds()
+	if b.Dx() <= width || width < 1 {
+		return src
+	}
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		sy0 := b.Min.Y + y*b.Dy()/height
+		sy1 := b.Min.Y + (y+1)*b.Dy()/height
+		for x := 0; x < width; x++ {
+			sx0 := b.Min.X + x*b.Dx()/width
+			sx1 := b.Min.X + (x+1)*b.Dx()/width
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(pr), g+uint64(pg), bl+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			if n == 0 {
+				continue
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
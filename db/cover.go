@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/danlock/feedgen/cover"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/pkg/errors"
+)
+
+// MangaCover is the cached cover image of a manga.
+type MangaCover struct {
+	cover.Image
+	MUID      int
+	SourceURL string    `db:"source_url"`
+	FetchedAt time.Time `db:"fetched_at"`
+}
+
+// UpsertCover stores img as the cover of muid, downloaded from sourceURL.
+// Images are stored once per hash, so manga sharing a cover share the image.
+func (m *mangaStore) UpsertCover(ctx context.Context, muid int, sourceURL string, img cover.Image) error {
+	imageQuery := `
+	INSERT INTO coverimage (hash, content_type, image, thumbnail) VALUES (?,?,?,?)
+	ON CONFLICT (hash) DO NOTHING;
+	`
+	imageQuery = m.db.Rebind(imageQuery)
+	if _, err := m.db.ExecContext(ctx, imageQuery, img.Hash, img.ContentType, img.Data, img.Thumbnail); err != nil {
+		logger.Errf(ctx, "Failed to upsert cover image with %s err %s", imageQuery, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	coverQuery := `
+	UPSERT INTO mangacover (muid, hash, source_url, fetched_at) VALUES (?,?,?,?);
+	`
+	coverQuery = m.db.Rebind(coverQuery)
+	if _, err := m.db.ExecContext(ctx, coverQuery, muid, img.Hash, sourceURL, time.Now().UTC()); err != nil {
+		logger.Errf(ctx, "Failed to upsert manga cover with %s err %s", coverQuery, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// GetCoverSourceURL returns the URL the cached cover of muid was downloaded from, or an empty string if it has none.
+func (m *mangaStore) GetCoverSourceURL(ctx context.Context, muid int) (string, error) {
+	query := `
+	SELECT source_url FROM mangacover WHERE muid=?;
+	`
+	query = m.db.Rebind(query)
+	var sourceURL string
+	if err := m.db.GetContext(ctx, &sourceURL, query, muid); err == sql.ErrNoRows {
+		return "", nil
+	} else if err != nil {
+		logger.Errf(ctx, "Failed to get cover source url with %s err %s", query, ErrDetails(err))
+		return "", errors.WithStack(err)
+	}
+	return sourceURL, nil
+}
+
+// GetCover returns the cached cover of muid, or sql.ErrNoRows if it has none.
+func (m *mangaStore) GetCover(ctx context.Context, muid int) (MangaCover, error) {
+	query := `
+	SELECT mc.muid, mc.source_url, mc.fetched_at, ci.hash, ci.content_type, ci.image, ci.thumbnail
+	FROM mangacover mc JOIN coverimage ci ON mc.hash = ci.hash
+	WHERE mc.muid=?;
+	`
+	query = m.db.Rebind(query)
+	var mc MangaCover
+	if err := m.db.GetContext(ctx, &mc, query, muid); err == sql.ErrNoRows {
+		return mc, err
+	} else if err != nil {
+		logger.Errf(ctx, "Failed to get cover with %s err %s", query, ErrDetails(err))
+		return mc, errors.WithStack(err)
+	}
+	return mc, nil
+}
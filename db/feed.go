@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// Editable reports whether the feed's manga can be changed by the holder of its owner token.
+func (mf MangaFeed) Editable() bool { return mf.OwnerTokenHash.Valid }
+
+// OwnedBy reports whether ownerToken is the token returned when the editable feed was created.
+func (mf MangaFeed) OwnedBy(ownerToken string) bool {
+	if !mf.Editable() || ownerToken == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashOwnerToken(ownerToken)), []byte(mf.OwnerTokenHash.String)) == 1
+}
+
+// hashOwnerToken hashes a token so a leaked database doesn't leak the power to edit feeds.
+// Tokens are random, so they don't need a slow hash.
+func hashOwnerToken(ownerToken string) string {
+	h := sha256.Sum256([]byte(ownerToken))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// randomString returns n random bytes encoded for use in URLs.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "Failed reading random bytes")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// feedHashPrefix versions the hashes UpsertFeed gives feeds. Hashes from before versioning have no prefix.
+const feedHashPrefix = "v2."
+
+// legacyFeedHashLength is the length of the hashes UpsertFeed gave feeds before feedHashPrefix,
+// the sha256 of their muids concatenated without a separator, so {1,23} and {12,3} got the same hash.
+const legacyFeedHashLength = 43
+
+// feedHash hashes sorted muids, each followed by a comma so no two sets of muids hash the same input.
+func feedHash(muids pq.Int64Array) string {
+	h := sha256.New()
+	for _, muid := range muids {
+		h.Write([]byte(strconv.FormatInt(muid, 10) + ","))
+	}
+	return feedHashPrefix + base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// sortedUniqueMUIDs returns muids sorted without duplicates, the way feeds store them.
+func sortedUniqueMUIDs(muids []int) pq.Int64Array {
+	sort.Ints(muids)
+	out := make(pq.Int64Array, 0, len(muids))
+	for i, muid := range muids {
+		if i == 0 || muid != muids[i-1] {
+			out = append(out, int64(muid))
+		}
+	}
+	return out
+}
+
+// NewFeed is a feed to be created with a random hash, unlike the feeds UpsertFeed hashes from their muids.
+type NewFeed struct {
+	MUIDs       []int
+	Name        string
+	Description string
+	// Editable feeds can be changed by whoever holds the owner token returned by CreateFeed
+	Editable bool
+	Private  bool
+}
+
+// CreateFeed creates nf with a random 128-bit hash. Nobody can find the feed without being given its hash,
+// and the hash stays the same when an editable feed's manga are changed by UpdateFeed.
+// The returned ownerToken is only set for editable feeds, and can't be recovered if it's lost.
+func (m *mangaStore) CreateFeed(ctx context.Context, nf NewFeed) (hash, ownerToken string, err error) {
+	// 16 random bytes encode to 22 characters, which can't clash with the 43 character hashes from UpsertFeed
+	if hash, err = randomString(16); err != nil {
+		return "", "", err
+	}
+	var ownerTokenHash sql.NullString
+	if nf.Editable {
+		if ownerToken, err = randomString(32); err != nil {
+			return "", "", err
+		}
+		ownerTokenHash = sql.NullString{String: hashOwnerToken(ownerToken), Valid: true}
+	}
+	query := `
+	INSERT INTO mangafeed (hash, muids, name, description, owner_token_hash, private, updated_at) VALUES (?,?,?,?,?,?,?);
+	`
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, hash, sortedUniqueMUIDs(nf.MUIDs), nf.Name, nf.Description, ownerTokenHash, nf.Private, time.Now().UTC()); err != nil {
+		logger.Errf(ctx, "Failed to create feed with %s err %s", query, ErrDetails(err))
+		return "", "", errors.WithStack(err)
+	}
+	return hash, ownerToken, nil
+}
+
+// UpdateFeed saves the manga, name and description of an editable feed. Feeds that can't be edited are left unchanged.
+func (m *mangaStore) UpdateFeed(ctx context.Context, feed MangaFeed) error {
+	muids := make([]int, len(feed.MUIDs))
+	for i, muid := range feed.MUIDs {
+		muids[i] = int(muid)
+	}
+	query := `
+	UPDATE mangafeed SET muids=?, name=?, description=?, updated_at=? WHERE hash=? AND owner_token_hash IS NOT NULL;
+	`
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, sortedUniqueMUIDs(muids), feed.Name, feed.Description, time.Now().UTC(), feed.Hash); err != nil {
+		logger.Errf(ctx, "Failed to update feed with %s err %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteFeed deletes an editable feed. Feeds that can't be edited may be shared by anyone who asked for the same manga, so they're never deleted.
+func (m *mangaStore) DeleteFeed(ctx context.Context, hash string) error {
+	query := `
+	DELETE FROM mangafeed WHERE hash=? AND owner_token_hash IS NOT NULL;
+	`
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, hash); err != nil {
+		logger.Errf(ctx, "Failed to delete feed with %s err %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// FindLegacyFeeds returns the feeds hashed before feedHashPrefix, ordered by hash and starting after afterHash.
+func (m *mangaStore) FindLegacyFeeds(ctx context.Context, afterHash string, limit int) ([]MangaFeed, error) {
+	query := `
+	SELECT hash, muids, created_at FROM mangafeed
+	WHERE length(hash) = ? AND owner_token_hash IS NULL AND NOT private AND hash > ?
+	ORDER BY hash LIMIT ?;
+	`
+	query = m.db.Rebind(query)
+	feeds := make([]MangaFeed, 0, limit)
+	if err := m.db.SelectContext(ctx, &feeds, query, legacyFeedHashLength, afterHash, limit); err != nil {
+		logger.Errf(ctx, "Failed to find legacy feeds with %s err %s", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	return feeds, nil
+}
+
+// UpsertFeedCollision records that collidingMUIDs had the same legacy hash as the feed for muids,
+// so whoever asked for collidingMUIDs may have been given that feed instead.
+// There's no telling which of them a request for the hash comes from, so GetFeed returns collidingMUIDs for the API to refuse it.
+func (m *mangaStore) UpsertFeedCollision(ctx context.Context, hash string, muids, collidingMUIDs []int64) error {
+	query := `
+	UPSERT INTO mangafeedcollision (hash, muids, colliding_muids, detected_at) VALUES (?,?,?,?);
+	`
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, hash, pq.Int64Array(muids), pq.Int64Array(collidingMUIDs), time.Now().UTC()); err != nil {
+		logger.Errf(ctx, "Failed to upsert feed collision with %s err %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type ScanlationGroup struct {
+	ReleaseID int64  `db:"release_id" json:"-"`
+	GroupID   int    `db:"group_id" json:"id"`
+	Name      string `db:"name" json:"name"`
+}
+
+// releaseKey identifies a release by the columns of the mangarelease unique index.
+type releaseKey struct {
+	muid        int
+	release     string
+	translators string
+}
+
+// upsertReleaseGroups saves the scanlation groups of already upserted releases and links them to those releases.
+// releaseIDs are the ids the upsert returned, releases missing from it aren't linked.
+func (m *mangaStore) upsertReleaseGroups(ctx context.Context, releases []scrape.MangaRelease, releaseIDs map[releaseKey]int64) error {
+	groupValues := ""
+	groupArgs := make([]interface{}, 0)
+	seenGroups := make(map[int]struct{})
+	for _, r := range releases {
+		if _, upserted := releaseIDs[releaseKey{r.MUID, r.Release, r.Translators}]; !upserted {
+			continue
+		}
+		for _, g := range r.Groups {
+			if _, seen := seenGroups[g.ID]; seen || g.ID < 1 {
+				continue
+			}
+			seenGroups[g.ID] = struct{}{}
+			groupValues += " (?,?),"
+			groupArgs = append(groupArgs, g.ID, g.Name)
+		}
+	}
+	if len(seenGroups) == 0 {
+		return nil
+	}
+	groupQuery := m.db.Rebind(fmt.Sprintf("UPSERT INTO scanlation_group (group_id, name) VALUES %s;", groupValues[:len(groupValues)-1]))
+	if _, err := m.db.ExecContext(ctx, groupQuery, groupArgs...); err != nil {
+		logger.Errf(ctx, "Failed upserting scanlation groups with %s err: %s", groupQuery, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	linkValues := ""
+	linkArgs := make([]interface{}, 0)
+	for _, r := range releases {
+		id, found := releaseIDs[releaseKey{r.MUID, r.Release, r.Translators}]
+		if !found {
+			continue
+		}
+		for _, g := range r.Groups {
+			if g.ID < 1 {
+				continue
+			}
+			linkValues += " (?,?),"
+			linkArgs = append(linkArgs, id, g.ID)
+		}
+	}
+	if len(linkArgs) == 0 {
+		return nil
+	}
+	linkQuery := m.db.Rebind(fmt.Sprintf("UPSERT INTO mangarelease_group (release_id, group_id) VALUES %s;", linkValues[:len(linkValues)-1]))
+	if _, err := m.db.ExecContext(ctx, linkQuery, linkArgs...); err != nil {
+		logger.Errf(ctx, "Failed linking releases to scanlation groups with %s err: %s", linkQuery, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// FindGroupsForReleases returns the scanlation groups of each release, keyed by release id.
+func (m *mangaStore) FindGroupsForReleases(ctx context.Context, releaseIDs []int64) (map[int64][]ScanlationGroup, error) {
+	groupsByRelease := make(map[int64][]ScanlationGroup)
+	if len(releaseIDs) == 0 {
+		return groupsByRelease, nil
+	}
+	query := `
+	SELECT mangarelease_group.release_id, scanlation_group.group_id, scanlation_group.name
+	FROM mangarelease_group
+	INNER JOIN scanlation_group ON scanlation_group.group_id=mangarelease_group.group_id
+	WHERE mangarelease_group.release_id = ANY ?;
+	`
+	query = m.db.Rebind(query)
+	groups := make([]ScanlationGroup, 0, len(releaseIDs))
+	if err := m.db.SelectContext(ctx, &groups, query, pq.Int64Array(releaseIDs)); err != nil {
+		logger.Errf(ctx, "Failed to find scanlation groups with %s err: %s", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	for _, g := range groups {
+		groupsByRelease[g.ReleaseID] = append(groupsByRelease[g.ReleaseID], g)
+	}
+	return groupsByRelease, nil
+}
@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// MangaChange is a single change to a manga's metadata noticed while scraping it again.
+// Changes to lists like titles or genres are recorded per value, with OldValue empty for additions and NewValue empty for removals.
+type MangaChange struct {
+	MUID      int
+	Field     string
+	OldValue  string    `db:"old_value"`
+	NewValue  string    `db:"new_value"`
+	ChangedAt time.Time `db:"changed_at"`
+}
+
+// diffManga returns every change between a manga's stored metadata and active titles and a fresh scrape of it.
+// The latest release isn't metadata and changes too often to be worth recording, so it's left out.
+func diffManga(before MangaMetadata, beforeTitles []string, after scrape.MangaInfo, at time.Time) []MangaChange {
+	changes := make([]MangaChange, 0)
+	scalar := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, MangaChange{MUID: after.MUID, Field: field, OldValue: old, NewValue: new, ChangedAt: at})
+		}
+	}
+	list := func(field string, old, new []string) {
+		oldSet := make(map[string]bool, len(old))
+		for _, v := range old {
+			oldSet[v] = true
+		}
+		newSet := make(map[string]bool, len(new))
+		for _, v := range new {
+			if !newSet[v] && !oldSet[v] {
+				changes = append(changes, MangaChange{MUID: after.MUID, Field: field, NewValue: v, ChangedAt: at})
+			}
+			newSet[v] = true
+		}
+		for v := range oldSet {
+			if !newSet[v] {
+				changes = append(changes, MangaChange{MUID: after.MUID, Field: field, OldValue: v, ChangedAt: at})
+			}
+		}
+	}
+	scalar("display_title", before.DisplayTitle, after.DisplayTitle)
+	scalar("status", before.Status, after.Status)
+	scalar("type", before.Type, after.Type)
+	scalar("year", strconv.Itoa(before.Year), strconv.Itoa(after.Year))
+	scalar("cover_url", before.CoverURL, after.CoverURL)
+	list("title", beforeTitles, normalizeTitles(after.Titles))
+	list("genre", before.Genres, after.Genres)
+	list("category", before.Categories, after.Categories)
+	list(authorRole, before.Authors, after.Authors)
+	list(artistRole, before.Artists, after.Artists)
+	return changes
+}
+
+// normalizeTitles formats titles the way they are stored in mangatitle, dropping duplicates.
+func normalizeTitles(titles []string) []string {
+	normalized := make([]string, 0, len(titles))
+	seen := make(map[string]struct{}, len(titles))
+	for _, t := range titles {
+		t = strings.TrimSpace(strings.ToLower(t))
+		if _, dup := seen[t]; dup || t == "" {
+			continue
+		}
+		seen[t] = struct{}{}
+		normalized = append(normalized, t)
+	}
+	return normalized
+}
+
+// findActiveTitles returns the titles of each manga that haven't been retired, keyed by muid.
+func (m *mangaStore) findActiveTitles(ctx context.Context, muids pq.Int64Array) (map[int][]string, error) {
+	query := `
+	SELECT muid, title FROM mangatitle WHERE muid = ANY ? AND retired_at IS NULL;
+	`
+	query = m.db.Rebind(query)
+	rows := make([]MangaTitle, 0)
+	if err := m.db.SelectContext(ctx, &rows, query, muids); err != nil {
+		logger.Errf(ctx, "Failed getting active titles with %s err:%+v", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	titles := make(map[int][]string, len(muids))
+	for _, r := range rows {
+		titles[r.MUID] = append(titles[r.MUID], r.OriginalTitle)
+	}
+	return titles, nil
+}
+
+// retireMissingTitles retires the titles of each manga that are no longer listed on its latest scrape.
+// Retired titles are kept so they are restored if they show up again, but aren't matched against anymore.
+func (m *mangaStore) retireMissingTitles(ctx context.Context, manga []scrape.MangaInfo, at time.Time) error {
+	query := `
+	UPDATE mangatitle SET retired_at = ? WHERE muid = ? AND retired_at IS NULL AND NOT (title = ANY ?);
+	`
+	query = m.db.Rebind(query)
+	for _, mi := range manga {
+		if mi.MUID < 1 || len(mi.Titles) == 0 {
+			continue
+		}
+		if _, err := m.db.ExecContext(ctx, query, at, mi.MUID, pq.StringArray(normalizeTitles(mi.Titles))); err != nil {
+			logger.Errf(ctx, "Failed retiring titles with %s err: %s", query, ErrDetails(err))
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// insertMangaChanges records changes in mangahistory.
+func (m *mangaStore) insertMangaChanges(ctx context.Context, changes []MangaChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	values := ""
+	args := make([]interface{}, 0, len(changes)*5)
+	for _, c := range changes {
+		values += " (?,?,?,?,?),"
+		args = append(args, c.MUID, c.Field, c.OldValue, c.NewValue, c.ChangedAt)
+	}
+	query := fmt.Sprintf("INSERT INTO mangahistory (muid, field, old_value, new_value, changed_at) VALUES %s;", values[:len(values)-1])
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, args...); err != nil {
+		logger.Errf(ctx, "Failed inserting manga history with %s err: %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// FindMangaHistory returns every recorded change to the metadata of muid, newest first.
+func (m *mangaStore) FindMangaHistory(ctx context.Context, muid int) ([]MangaChange, error) {
+	query := `
+	SELECT muid, field, old_value, new_value, changed_at FROM mangahistory WHERE muid=? ORDER BY changed_at DESC, id DESC;
+	`
+	query = m.db.Rebind(query)
+	changes := make([]MangaChange, 0)
+	if err := m.db.SelectContext(ctx, &changes, query, muid); err != nil {
+		logger.Errf(ctx, "Failed getting manga history with %s err:%+v", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	return changes, nil
+}
+
+// FindStaleManga returns up to limit muids of the unretired manga least recently scraped, as long as that was before staleBefore.
+// Manga never scraped since scraped_at was added sort first, since CockroachDB orders NULLs before other values.
+func (m *mangaStore) FindStaleManga(ctx context.Context, staleBefore time.Time, limit int) ([]int, error) {
+	query := `
+	SELECT muid FROM manga WHERE retired_at IS NULL AND (scraped_at IS NULL OR scraped_at < ?) ORDER BY scraped_at ASC LIMIT ?;
+	`
+	query = m.db.Rebind(query)
+	muids := make([]int, 0, limit)
+	if err := m.db.SelectContext(ctx, &muids, query, staleBefore, limit); err != nil {
+		logger.Errf(ctx, "Failed finding stale manga with %s err:%+v", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	return muids, nil
+}
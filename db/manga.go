@@ -2,15 +2,12 @@ package db
 
 import (
 	"context"
-	"crypto/sha256"
 	"database/sql"
-	"encoding/base64"
 	"fmt"
-	"sort"
-	"strconv"
 	"strings"
 	"time"
 
+	"github.com/danlock/feedgen/cover"
 	"github.com/danlock/feedgen/lib/logger"
 	"github.com/danlock/feedgen/scrape"
 	"github.com/jmoiron/sqlx"
@@ -21,13 +18,54 @@ import (
 type MangaStorer interface {
 	FindMangaByTitlesIntoMangaTitlesSlice(context.Context, []string) ([]MangaTitle, error)
 	FindMangaByTitles(context.Context, []string, interface{}) error
-	FindReleasesForFeed(context.Context, MangaFeed, interface{}) error
+	FindMangaByFoldedTitles(ctx context.Context, folded []string) ([]MangaTitle, error)
+	FindTitleCandidates(ctx context.Context, title string, limit int) ([]TitleCandidate, error)
+	FoldMissingTitles(ctx context.Context, limit int) (int, error)
+	ParseStaleReleases(ctx context.Context, limit int) (int, error)
+	FindReleasesForFeed(context.Context, MangaFeed, ReleaseFilter, interface{}) error
+	FindGroupsForReleases(ctx context.Context, releaseIDs []int64) (map[int64][]ScanlationGroup, error)
 	UpsertManga(context.Context, []scrape.MangaInfo) error
 	UpsertRelease(context.Context, []scrape.MangaRelease) error
+	UpsertReparsedReleases(ctx context.Context, releases []scrape.MangaRelease) error
 	FilterOutReleasesWithoutMangaInDB(context.Context, []scrape.MangaRelease) ([]scrape.MangaRelease, error)
 	UpsertFeed(context.Context, []int) (string, error)
 	GetFeed(context.Context, string, interface{}) error
+	CreateFeed(ctx context.Context, nf NewFeed) (hash, ownerToken string, err error)
+	UpdateFeed(ctx context.Context, feed MangaFeed) error
+	DeleteFeed(ctx context.Context, hash string) error
+	FindLegacyFeeds(ctx context.Context, afterHash string, limit int) ([]MangaFeed, error)
+	UpsertFeedCollision(ctx context.Context, hash string, muids, collidingMUIDs []int64) error
+	FindMUIDs(ctx context.Context) ([]int64, error)
 	FindMangaByMUIDs(ctx context.Context, muids pq.Int64Array, outPtr interface{}) error
+	FindMangaMetadata(ctx context.Context, muids pq.Int64Array) (map[int]MangaMetadata, error)
+	ClaimMUIDSource(ctx context.Context, source string) (string, error)
+	GetMUIDSource(ctx context.Context) (string, error)
+	GetLastPoll(ctx context.Context, source string) (time.Time, error)
+	SetLastPoll(ctx context.Context, source string, at time.Time) error
+	FindMangaHistory(ctx context.Context, muid int) ([]MangaChange, error)
+	FindStaleManga(ctx context.Context, staleBefore time.Time, limit int) ([]int, error)
+	MergeManga(ctx context.Context, from, into int) error
+	RetireManga(ctx context.Context, muid int) error
+	ResolveMUIDs(ctx context.Context, muids pq.Int64Array) (map[int]int, error)
+	UpsertCover(ctx context.Context, muid int, sourceURL string, img cover.Image) error
+	GetCoverSourceURL(ctx context.Context, muid int) (string, error)
+	GetCover(ctx context.Context, muid int) (MangaCover, error)
+	GetPopulateCheckpoint(ctx context.Context, source string, start, end int) (int, error)
+	SetPopulateCheckpoint(ctx context.Context, source string, start, end, next int) error
+	FindPopulateFailures(ctx context.Context, source string, start, end int) ([]int, error)
+	UpsertPopulateFailure(ctx context.Context, source string, muid int, scrapeErr error) error
+	DeletePopulateFailure(ctx context.Context, source string, muid int) error
+	SaveSnapshot(ctx context.Context, snap scrape.Snapshot) error
+	FindSnapshots(ctx context.Context, source string, kinds []scrape.SnapshotKind, from, to time.Time, afterID int64, limit int) ([]PageSnapshot, error)
+	FindLatestSeriesSnapshots(ctx context.Context, source string, from, to time.Time, afterID int64, limit int) ([]PageSnapshot, error)
+	InsertScrapeRun(ctx context.Context, run scrape.ScrapeRun) error
+	FindScrapeRuns(ctx context.Context, source string, limit int) ([]scrape.ScrapeRun, error)
+	FindReleaseTitleMappings(ctx context.Context, source string, titles []string) (map[string]int, error)
+	UpsertReleaseTitleMapping(ctx context.Context, source, title string, muid int, resolvedBy string) error
+	QueueUnresolvedReleases(ctx context.Context, source string, releases []scrape.MangaRelease) error
+	FindUnresolvedReleases(ctx context.Context, source, title string) ([]UnresolvedRelease, error)
+	DeleteUnresolvedReleases(ctx context.Context, source, title string) error
+	FindReleaseEvents(ctx context.Context, afterID int64, limit int) ([]ReleaseEvent, error)
 }
 
 type mangaStore struct {
@@ -38,33 +76,59 @@ func NewMangaStore(db *sqlx.DB) MangaStorer {
 	return &mangaStore{db}
 }
 
+// UpsertManga inserts or updates manga with their latest scrape, retiring titles that are no longer listed
+// and recording any change to the metadata of manga already in the db in mangahistory.
 func (m *mangaStore) UpsertManga(ctx context.Context, manga []scrape.MangaInfo) error {
-	mangaQuery := `INSERT INTO manga (muid, latest_release, display_title) VALUES
+	mangaQuery := `INSERT INTO manga (muid, latest_release, display_title, status, "type", year, cover_url, scraped_at) VALUES
 %s
 ON CONFLICT (muid)
-DO NOTHING;`
-	titleQuery := "UPSERT INTO mangatitle (muid,title) VALUES %s;"
+DO UPDATE SET latest_release = excluded.latest_release, display_title = excluded.display_title, status = excluded.status,
+	"type" = excluded."type", year = excluded.year, cover_url = excluded.cover_url, scraped_at = excluded.scraped_at,
+	retired_at = NULL, merged_into = NULL;`
+	titleQuery := "UPSERT INTO mangatitle (muid,title,folded_title,trigram_count,retired_at) VALUES %s;"
 
-	muidReleaseArray := make([]interface{}, 0, len(manga)*2)
+	now := time.Now().UTC()
+	muids := make(pq.Int64Array, 0, len(manga))
+	muidReleaseArray := make([]interface{}, 0, len(manga)*8)
 	mangaValues := ""
 	muidTitleArray := make([]interface{}, 0)
 	titleValues := ""
+	titles := make([]foldedTitle, 0)
 	seenMUID := make(map[int]struct{})
 	for _, m := range manga {
 		if _, seen := seenMUID[m.MUID]; seen || m.MUID < 1 {
 			continue
 		}
 		seenMUID[m.MUID] = struct{}{}
-		mangaValues += fmt.Sprintf(" (?,?,?),")
-		muidReleaseArray = append(muidReleaseArray, m.MUID, m.LatestRelease, m.DisplayTitle)
-		for _, t := range m.Titles {
-			titleValues += fmt.Sprintf(" (?,?),")
-			muidTitleArray = append(muidTitleArray, m.MUID, strings.TrimSpace(strings.ToLower(t)))
+		muids = append(muids, int64(m.MUID))
+		mangaValues += fmt.Sprintf(" (?,?,?,?,?,?,?,?),")
+		scrapedAt := m.ScrapedAt
+		if scrapedAt.IsZero() {
+			scrapedAt = now
 		}
+		muidReleaseArray = append(muidReleaseArray, m.MUID, m.LatestRelease, m.DisplayTitle, m.Status, m.Type, m.Year, m.CoverURL, scrapedAt)
+		for _, t := range normalizeTitles(m.Titles) {
+			folded := FoldTitle(t)
+			titleValues += fmt.Sprintf(" (?,?,?,?,NULL),")
+			muidTitleArray = append(muidTitleArray, m.MUID, t, folded, len(trigrams(folded)))
+			titles = append(titles, foldedTitle{MUID: m.MUID, Title: t, Folded: folded})
+		}
+	}
+	if mangaValues == "" {
+		return nil
 	}
 	// Trim off trailing commas
 	mangaValues = mangaValues[:len(mangaValues)-1]
-	titleValues = titleValues[:len(titleValues)-1]
+
+	// Compare against what's stored before it gets overwritten
+	before, err := m.FindMangaMetadata(ctx, muids)
+	if err != nil {
+		return err
+	}
+	beforeTitles, err := m.findActiveTitles(ctx, muids)
+	if err != nil {
+		return err
+	}
 
 	mangaQuery = fmt.Sprintf(mangaQuery, mangaValues)
 	mangaQuery = m.db.Rebind(mangaQuery)
@@ -73,52 +137,159 @@ DO NOTHING;`
 		logger.Errf(ctx, "Failed upserting manga with %s\n with error %s", mangaQuery, ErrDetails(err))
 		return errors.WithStack(err)
 	}
-	titleQuery = fmt.Sprintf(titleQuery, titleValues)
-	titleQuery = m.db.Rebind(titleQuery)
-	if _, err := m.db.ExecContext(ctx, titleQuery, muidTitleArray...); err != nil {
-		logger.Errf(ctx, "Failed upserting titles with %s\n with error %s", titleQuery, ErrDetails(err))
-		return errors.WithStack(err)
+	// Every title may have been dropped by normalizeTitles
+	if titleValues != "" {
+		titleQuery = m.db.Rebind(fmt.Sprintf(titleQuery, titleValues[:len(titleValues)-1]))
+		if _, err := m.db.ExecContext(ctx, titleQuery, muidTitleArray...); err != nil {
+			logger.Errf(ctx, "Failed upserting titles with %s\n with error %s", titleQuery, ErrDetails(err))
+			return errors.WithStack(err)
+		}
 	}
-	return nil
+	if err := m.upsertTitleTrigrams(ctx, titles); err != nil {
+		return err
+	}
+	if err := m.retireMissingTitles(ctx, manga, now); err != nil {
+		return err
+	}
+	if err := m.replaceMangaMetadata(ctx, manga); err != nil {
+		return err
+	}
+	changes := make([]MangaChange, 0)
+	for _, mi := range manga {
+		if mm, found := before[mi.MUID]; found {
+			changes = append(changes, diffManga(mm, beforeTitles[mi.MUID], mi, now)...)
+		}
+	}
+	return m.insertMangaChanges(ctx, changes)
 }
 
+// UpsertRelease inserts the releases that aren't in the db yet, identified by their muid, release and translators,
+// and logs each one in releaseevent. Releases without an MUID are skipped.
 func (m *mangaStore) UpsertRelease(ctx context.Context, releases []scrape.MangaRelease) error {
+	return m.upsertReleases(ctx, releases, false)
+}
+
+// UpsertReparsedReleases is UpsertRelease for releases parsed again from snapshots. The releases already in the db
+// have their release date and parsed columns overwritten as well, so a parser fix corrects them.
+func (m *mangaStore) UpsertReparsedReleases(ctx context.Context, releases []scrape.MangaRelease) error {
+	return m.upsertReleases(ctx, releases, true)
+}
+
+// upsertReleases inserts releases and logs the new ones, also overwriting the ones already in the db if overwrite is set.
+func (m *mangaStore) upsertReleases(ctx context.Context, releases []scrape.MangaRelease, overwrite bool) (err error) {
 	releaseQuery := `
-	INSERT INTO mangarelease (muid, release, translators)
-		%s
+	INSERT INTO mangarelease (muid, release, translators, released_at, volume, chapter_start, chapter_end, is_oneshot, is_extra, is_omake, parser_version)
+		VALUES %s
 	ON CONFLICT (muid,release,translators)
-	DO NOTHING;
+	DO NOTHING
+	RETURNING id AS release_id, muid, release, translators, released_at;
 	`
-	releaseValues := "VALUES"
-	valuesArr := make([]interface{}, 0, len(releases)*3)
-	releaesMissingMUIDs := 0
-	seenMUID := make(map[int]struct{})
+	releaseValues := ""
+	valuesArr := make([]interface{}, 0, len(releases)*11)
+	releasesMissingMUIDs := 0
+	seen := make(map[releaseKey]struct{})
+	upserted := make([]scrape.MangaRelease, 0, len(releases))
 	for _, r := range releases {
-		if _, seen := seenMUID[r.MUID]; seen || r.MUID < 1 {
-			releaesMissingMUIDs++
+		if r.MUID < 1 {
+			releasesMissingMUIDs++
 			continue
 		}
-		valuesArr = append(valuesArr, r.MUID, r.Release, r.Translators)
-		releaseValues += " (?,?,?),"
-		seenMUID[r.MUID] = struct{}{}
-
+		// A series often gets several releases in one batch, so only drop exact duplicates
+		key := releaseKey{r.MUID, r.Release, r.Translators}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		upserted = append(upserted, r)
+		releasedAt := r.ReleasedAt
+		if releasedAt.IsZero() {
+			releasedAt = r.CreatedAt
+		}
+		ri := scrape.ParseRelease(r.Release)
+		valuesArr = append(valuesArr, r.MUID, r.Release, r.Translators, releasedAt,
+			ri.Volume, ri.ChapterStart, ri.ChapterEnd, ri.Oneshot, ri.Extra, ri.Omake, scrape.ReleaseParserVersion)
+		releaseValues += " (?,?,?,?,?,?,?,?,?,?,?),"
 	}
-	if releaesMissingMUIDs > 0 {
-		logger.Dbgf(ctx, "Skipping %d releases missing MUIDs", releaesMissingMUIDs)
+	if releasesMissingMUIDs > 0 {
+		logger.Dbgf(ctx, "Skipping %d releases missing MUIDs", releasesMissingMUIDs)
 	}
-	logger.Dbgf(ctx, "Preparing to upsert %d releases", len(seenMUID))
+	if len(upserted) == 0 {
+		return nil
+	}
+	logger.Dbgf(ctx, "Preparing to upsert %d releases", len(upserted))
 
-	releaseValues = releaseValues[:len(releaseValues)-1]
-	releaseQuery = fmt.Sprintf(releaseQuery, releaseValues)
-	releaseQuery = m.db.Rebind(releaseQuery)
-	results, err := m.db.ExecContext(ctx, releaseQuery, valuesArr...)
+	releaseQuery = m.db.Rebind(fmt.Sprintf(releaseQuery, releaseValues[:len(releaseValues)-1]))
+	tx, err := m.db.BeginTxx(ctx, nil)
 	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	inserted, overwritten := make([]ReleaseEvent, 0, len(upserted)), make([]ReleaseEvent, 0)
+	if err = tx.SelectContext(ctx, &inserted, releaseQuery, valuesArr...); err != nil {
 		logger.Errf(ctx, "Failed to upsert release with query %s and err: %+v", releaseQuery, ErrDetails(err))
 		return errors.WithStack(err)
 	}
-	rowsA, rowsE := results.RowsAffected()
-	logger.Dbgf(ctx, "Upserted %d releases, affecting %d rows, with rows err %+v", len(releases)-releaesMissingMUIDs, rowsA, rowsE)
-	return nil
+	// The releases just inserted are overwritten with the same values, which only costs a few writes
+	if overwrite {
+		overwriteQuery := `
+		INSERT INTO mangarelease (muid, release, translators, released_at, volume, chapter_start, chapter_end, is_oneshot, is_extra, is_omake, parser_version)
+			VALUES %s
+		ON CONFLICT (muid,release,translators)
+		DO UPDATE SET released_at = excluded.released_at, volume = excluded.volume, chapter_start = excluded.chapter_start,
+			chapter_end = excluded.chapter_end, is_oneshot = excluded.is_oneshot, is_extra = excluded.is_extra,
+			is_omake = excluded.is_omake, parser_version = excluded.parser_version
+		RETURNING id AS release_id, muid, release, translators, released_at;
+		`
+		overwriteQuery = tx.Rebind(fmt.Sprintf(overwriteQuery, releaseValues[:len(releaseValues)-1]))
+		if err = tx.SelectContext(ctx, &overwritten, overwriteQuery, valuesArr...); err != nil {
+			logger.Errf(ctx, "Failed to overwrite releases with query %s and err: %+v", overwriteQuery, ErrDetails(err))
+			return errors.WithStack(err)
+		}
+	}
+	if err = m.insertReleaseEvents(ctx, tx, inserted, time.Now().UTC()); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Errf(ctx, "Failed committing releases err: %s", ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	logger.Dbgf(ctx, "Upserted %d releases, %d of them new", len(upserted), len(inserted))
+	// The groups of releases already in the db were linked when they were inserted, unless they were just overwritten
+	releaseIDs := make(map[releaseKey]int64, len(inserted)+len(overwritten))
+	for _, e := range append(inserted, overwritten...) {
+		releaseIDs[releaseKey{e.MUID, e.Release, e.Translators}] = e.ReleaseID
+	}
+	return m.upsertReleaseGroups(ctx, upserted, releaseIDs)
+}
+
+// ParseStaleReleases parses the release text of up to limit releases again, filling in the parsed columns of those parsed
+// by an older scrape.ReleaseParserVersion or not parsed at all. It returns how many releases were parsed.
+func (m *mangaStore) ParseStaleReleases(ctx context.Context, limit int) (int, error) {
+	query := `
+	SELECT id, release FROM mangarelease WHERE parser_version < ? LIMIT ?;
+	`
+	query = m.db.Rebind(query)
+	releases := make([]MangaRelease, 0, limit)
+	if err := m.db.SelectContext(ctx, &releases, query, scrape.ReleaseParserVersion, limit); err != nil {
+		logger.Errf(ctx, "Failed to find stale releases with %s err %s", query, ErrDetails(err))
+		return 0, errors.WithStack(err)
+	}
+	update := m.db.Rebind(`
+	UPDATE mangarelease SET volume = ?, chapter_start = ?, chapter_end = ?, is_oneshot = ?, is_extra = ?, is_omake = ?, parser_version = ?
+	WHERE id = ?;
+	`)
+	for _, r := range releases {
+		ri := scrape.ParseRelease(r.Release)
+		if _, err := m.db.ExecContext(ctx, update, ri.Volume, ri.ChapterStart, ri.ChapterEnd, ri.Oneshot, ri.Extra, ri.Omake, scrape.ReleaseParserVersion, r.ID); err != nil {
+			logger.Errf(ctx, "Failed to parse release with %s err %s", update, ErrDetails(err))
+			return 0, errors.WithStack(err)
+		}
+	}
+	return len(releases), nil
 }
 
 type MangaTitle struct {
@@ -139,7 +310,7 @@ func (m *mangaStore) FindMangaByTitles(ctx context.Context, titles []string, out
 	SELECT manga.muid,mangatitle.title,manga.display_title
 	FROM mangatitle
 	INNER JOIN manga ON manga.muid=mangatitle.muid
-	WHERE title IN (?);
+	WHERE title IN (?) AND mangatitle.retired_at IS NULL AND manga.retired_at IS NULL;
 	`
 	titleQuery, args, err := sqlx.In(titleQueryRaw, titles)
 	if err != nil {
@@ -170,26 +341,127 @@ func (m *mangaStore) FindMangaByMUIDs(ctx context.Context, muids pq.Int64Array,
 }
 
 type MangaRelease struct {
+	ID          int64
 	MUID        int
 	Title       string `db:"display_title"`
 	Release     string
 	Translators string
-	CreatedAt   time.Time `db:"created_at"`
+	// CreatedAt is when the release was first seen, ReleasedAt is when it was actually released.
+	CreatedAt  time.Time `db:"created_at"`
+	ReleasedAt time.Time `db:"released_at"`
+	scrape.ReleaseInfo
+	// Groups is not filled in by FindReleasesForFeed, use FindGroupsForReleases
+	Groups []ScanlationGroup `db:"-"`
+}
+
+// ReleaseFilter narrows down the releases found for a feed. The zero value doesn't filter or limit anything.
+type ReleaseFilter struct {
+	// GroupIDs only allows releases translated by at least one of these scanlation groups
+	GroupIDs []int64
+	// ExcludeGroupIDs removes releases translated by any of these scanlation groups
+	ExcludeGroupIDs []int64
+	// Genres only allows releases of manga with at least one of these genres, ignoring case
+	Genres []string
+	// ExcludeGenres removes releases of manga with any of these genres, ignoring case
+	ExcludeGenres []string
+	// Since only allows releases released at or after it
+	Since time.Time
+	// PerSeries only allows the latest PerSeries releases of each manga that pass the rest of the filter
+	PerSeries int
+	// Limit is the most releases to return, newest first
+	Limit int
+	// Before only allows releases older than it, and After only allows the Limit releases just newer than it
+	Before *ReleaseCursor
+	After  *ReleaseCursor
+}
+
+// ReleaseCursor is the position of a release in a feed, which is ordered by released_at and then id.
+// Paging by cursor instead of by offset keeps pages stable as newer releases come in.
+type ReleaseCursor struct {
+	ReleasedAt time.Time
+	ID         int64
 }
 
-func (m *mangaStore) FindReleasesForFeed(ctx context.Context, mf MangaFeed, outPtr interface{}) error {
+// where returns the SQL conditions for the filter on mangarelease, to be ANDed onto a WHERE clause, and its args.
+func (rf ReleaseFilter) where() (string, []interface{}) {
+	where := ""
+	args := make([]interface{}, 0, 2)
+	if len(rf.GroupIDs) > 0 {
+		where += " AND mangarelease.id IN (SELECT release_id FROM mangarelease_group WHERE group_id = ANY ?)"
+		args = append(args, pq.Int64Array(rf.GroupIDs))
+	}
+	if len(rf.ExcludeGroupIDs) > 0 {
+		where += " AND mangarelease.id NOT IN (SELECT release_id FROM mangarelease_group WHERE group_id = ANY ?)"
+		args = append(args, pq.Int64Array(rf.ExcludeGroupIDs))
+	}
+	if len(rf.Genres) > 0 {
+		where += " AND mangarelease.muid IN (SELECT muid FROM mangagenre WHERE lower(genre) = ANY ?)"
+		args = append(args, pq.StringArray(lowerAll(rf.Genres)))
+	}
+	if len(rf.ExcludeGenres) > 0 {
+		where += " AND mangarelease.muid NOT IN (SELECT muid FROM mangagenre WHERE lower(genre) = ANY ?)"
+		args = append(args, pq.StringArray(lowerAll(rf.ExcludeGenres)))
+	}
+	if !rf.Since.IsZero() {
+		where += " AND mangarelease.released_at >= ?"
+		args = append(args, rf.Since)
+	}
+	return where, args
+}
+
+func lowerAll(strs []string) []string {
+	lowered := make([]string, len(strs))
+	for i, s := range strs {
+		lowered[i] = strings.ToLower(strings.TrimSpace(s))
+	}
+	return lowered
+}
+
+// FindReleasesForFeed finds the releases of the manga in mf that pass rf, newest first.
+func (m *mangaStore) FindReleasesForFeed(ctx context.Context, mf MangaFeed, rf ReleaseFilter, outPtr interface{}) error {
+	// Pages after a cursor are the releases just newer than it, so they're found oldest first and then put back in order
 	releaseQuery := `
-	SELECT mangarelease.muid, mangarelease.release, mangarelease.translators, mangarelease.created_at, manga.display_title
+	SELECT * FROM (
+	SELECT id, muid, release, translators, created_at, released_at, display_title,
+		volume, chapter_start, chapter_end, is_oneshot, is_extra, is_omake
+	FROM (
+		SELECT mangarelease.id, mangarelease.muid, mangarelease.release, mangarelease.translators, mangarelease.created_at, mangarelease.released_at, manga.display_title,
+			mangarelease.volume, mangarelease.chapter_start, mangarelease.chapter_end,
+			mangarelease.is_oneshot, mangarelease.is_extra, mangarelease.is_omake,
+			row_number() OVER (PARTITION BY mangarelease.muid ORDER BY mangarelease.released_at DESC, mangarelease.id DESC) AS series_rank
 		FROM mangarelease
 		INNER JOIN manga ON mangarelease.muid=manga.muid
-		INNER JOIN (
-			SELECT muid, max(mangarelease.created_at) most_recent
-					FROM mangarelease
-					GROUP BY muid
-		) mr ON manga.muid = mr.muid AND mangarelease.created_at = mr.most_recent
-	WHERE mangarelease.muid = ANY ?;`
-	releaseQuery = m.db.Rebind(releaseQuery)
-	if err := m.db.SelectContext(ctx, outPtr, releaseQuery, mf.MUIDs); err != nil {
+		WHERE mangarelease.muid = ANY ?%s
+	) ranked
+	WHERE true%s
+	ORDER BY released_at %s, id %[3]s
+	%s
+	) page
+	ORDER BY released_at DESC, id DESC;`
+	filterWhere, filterArgs := rf.where()
+	args := append([]interface{}{mf.MUIDs}, filterArgs...)
+	rankWhere := ""
+	if rf.PerSeries > 0 {
+		rankWhere += " AND series_rank <= ?"
+		args = append(args, rf.PerSeries)
+	}
+	if rf.Before != nil {
+		rankWhere += " AND (released_at < ? OR (released_at = ? AND id < ?))"
+		args = append(args, rf.Before.ReleasedAt, rf.Before.ReleasedAt, rf.Before.ID)
+	}
+	order := "DESC"
+	if rf.After != nil {
+		rankWhere += " AND (released_at > ? OR (released_at = ? AND id > ?))"
+		args = append(args, rf.After.ReleasedAt, rf.After.ReleasedAt, rf.After.ID)
+		order = "ASC"
+	}
+	limit := ""
+	if rf.Limit > 0 {
+		limit = "LIMIT ?"
+		args = append(args, rf.Limit)
+	}
+	releaseQuery = m.db.Rebind(fmt.Sprintf(releaseQuery, filterWhere, rankWhere, order, limit))
+	if err := m.db.SelectContext(ctx, outPtr, releaseQuery, args...); err != nil {
 		logger.Errf(ctx, "Failed to find manga releases by titles with %s err: %s", releaseQuery, ErrDetails(err))
 		return errors.WithStack(err)
 	}
@@ -240,19 +512,26 @@ func (m *mangaStore) FilterOutReleasesWithoutMangaInDB(ctx context.Context, rele
 }
 
 type MangaFeed struct {
-	Hash      string
-	Type      string
-	MUIDs     pq.Int64Array `db:"muids"`
-	CreatedAt time.Time     `db:"created_at"`
+	Hash  string
+	Type  string
+	MUIDs pq.Int64Array `db:"muids"`
+	// Name and Description are only set on editable feeds
+	Name        string
+	Description string
+	// OwnerTokenHash is only set on editable feeds, see OwnedBy
+	OwnerTokenHash sql.NullString `db:"owner_token_hash"`
+	// Private feeds have a random hash, so they can only be found by whoever was given it
+	Private   bool
+	CreatedAt time.Time   `db:"created_at"`
+	UpdatedAt pq.NullTime `db:"updated_at"`
+	// CollidingMUIDs is only set on legacy feeds whose hash was also given out for another set of manga, see UpsertFeedCollision
+	CollidingMUIDs pq.Int64Array `db:"colliding_muids"`
 }
 
+// UpsertFeed returns the hash of the feed for muids, creating it if nobody asked for those manga before.
 func (m *mangaStore) UpsertFeed(ctx context.Context, muids []int) (string, error) {
-	sort.Ints(muids)
-	h := sha256.New()
-	for _, m := range muids {
-		h.Write([]byte(strconv.Itoa(m)))
-	}
-	hash := base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+	sortedMUIDs := sortedUniqueMUIDs(muids)
+	hash := feedHash(sortedMUIDs)
 	query := `
 	INSERT INTO mangafeed (hash, muids)
 	VALUES	(?,?)
@@ -260,7 +539,7 @@ func (m *mangaStore) UpsertFeed(ctx context.Context, muids []int) (string, error
 	DO NOTHING;
 `
 	query = m.db.Rebind(query)
-	_, err := m.db.ExecContext(ctx, query, hash, pq.Array(muids))
+	_, err := m.db.ExecContext(ctx, query, hash, sortedMUIDs)
 	if err != nil {
 		logger.Errf(ctx, "Failed to upsert feeds with %s err: %s", query, ErrDetails(err))
 		return "", errors.WithStack(err)
@@ -270,7 +549,10 @@ func (m *mangaStore) UpsertFeed(ctx context.Context, muids []int) (string, error
 
 func (m *mangaStore) GetFeed(ctx context.Context, hash string, outPtr interface{}) error {
 	query := `
-	SELECT muids,created_at FROM mangafeed WHERE hash=?;
+	SELECT f.hash,f.muids,f.name,f.description,f.owner_token_hash,f.private,f.created_at,f.updated_at,c.colliding_muids
+	FROM mangafeed f
+	LEFT JOIN mangafeedcollision c ON c.hash = f.hash
+	WHERE f.hash=?;
 	`
 	query = m.db.Rebind(query)
 	if err := m.db.GetContext(ctx, outPtr, query, hash); err != nil {
@@ -279,3 +561,16 @@ func (m *mangaStore) GetFeed(ctx context.Context, hash string, outPtr interface{
 	}
 	return nil
 }
+
+// FindMUIDs returns the muid of every manga in the db.
+func (m *mangaStore) FindMUIDs(ctx context.Context) ([]int64, error) {
+	query := `
+	SELECT muid FROM manga;
+	`
+	muids := make([]int64, 0)
+	if err := m.db.SelectContext(ctx, &muids, query); err != nil {
+		logger.Errf(ctx, "Failed to find muids with %s err %s", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	return muids, nil
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// MergeManga moves everything belonging to the manga from into the manga into, which must already be in the db, and retires from.
+// Feeds following from follow into instead, keeping their hash so existing feed URLs keep working. Nothing happens if from isn't in the db.
+func (m *mangaStore) MergeManga(ctx context.Context, from, into int) (err error) {
+	if from == into {
+		return nil
+	}
+	existsQuery := m.db.Rebind(`SELECT count(*) FROM manga WHERE muid = ?;`)
+	var exists int
+	if err := m.db.GetContext(ctx, &exists, existsQuery, from); err != nil {
+		logger.Errf(ctx, "Failed checking manga %d exists with %s err: %s", from, existsQuery, ErrDetails(err))
+		return errors.WithStack(err)
+	} else if exists == 0 {
+		return nil
+	}
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	now := time.Now().UTC()
+	queries := []struct {
+		query string
+		args  []interface{}
+	}{
+		// Releases both series have would violate mangarelease_un once moved, so the survivor's copy wins
+		{`DELETE FROM mangarelease WHERE muid = ? AND (release, translators) IN (SELECT release, translators FROM mangarelease WHERE muid = ?);`, []interface{}{from, into}},
+		{`UPDATE mangarelease SET muid = ? WHERE muid = ?;`, []interface{}{into, from}},
+		{`DELETE FROM mangatitle WHERE muid = ? AND title IN (SELECT title FROM mangatitle WHERE muid = ?);`, []interface{}{from, into}},
+		{`UPDATE mangatitle SET muid = ? WHERE muid = ?;`, []interface{}{into, from}},
+		// Feeds already following into just drop from, so into isn't in them twice
+		{`UPDATE mangafeed SET muids = CASE WHEN ?::INT = ANY(muids) THEN array_remove(muids, ?::INT) ELSE array_replace(muids, ?::INT, ?::INT) END WHERE ?::INT = ANY(muids);`, []interface{}{into, from, from, into, from}},
+		{`UPDATE manga SET retired_at = ?, merged_into = ? WHERE muid = ?;`, []interface{}{now, into, from}},
+		{`INSERT INTO mangahistory (muid, field, old_value, new_value, changed_at) SELECT muid, 'merged_into', '', ?, ? FROM manga WHERE muid = ?;`, []interface{}{strconv.Itoa(into), now, from}},
+	}
+	for _, q := range queries {
+		query := tx.Rebind(q.query)
+		if _, err = tx.ExecContext(ctx, query, q.args...); err != nil {
+			logger.Errf(ctx, "Failed merging manga %d into %d with %s err: %s", from, into, query, ErrDetails(err))
+			return errors.WithStack(err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Errf(ctx, "Failed committing merge of manga %d into %d err: %s", from, into, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// RetireManga marks muid as deleted from its source. Its releases and titles are kept, but it won't be refreshed or found by title anymore.
+func (m *mangaStore) RetireManga(ctx context.Context, muid int) error {
+	now := time.Now().UTC()
+	query := `
+	UPDATE manga SET retired_at = ? WHERE muid = ? AND retired_at IS NULL RETURNING muid;
+	`
+	query = m.db.Rebind(query)
+	retired := make([]int, 0, 1)
+	if err := m.db.SelectContext(ctx, &retired, query, now, muid); err != nil {
+		logger.Errf(ctx, "Failed retiring manga with %s err: %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	if len(retired) == 0 {
+		return nil
+	}
+	return m.insertMangaChanges(ctx, []MangaChange{{MUID: muid, Field: "retired", NewValue: "deleted", ChangedAt: now}})
+}
+
+// ResolveMUIDs returns the muid each of muids in the db is now, following the manga they were merged into.
+// Manga that were retired without being merged resolve to 0, and muids missing from the db are left out.
+func (m *mangaStore) ResolveMUIDs(ctx context.Context, muids pq.Int64Array) (map[int]int, error) {
+	query := `
+	SELECT muid, retired_at IS NOT NULL AS retired, COALESCE(merged_into, 0) AS merged_into FROM manga WHERE muid = ANY ?;
+	`
+	query = m.db.Rebind(query)
+	type mangaState struct {
+		MUID       int
+		Retired    bool
+		MergedInto int `db:"merged_into"`
+	}
+	states := make(map[int]mangaState, len(muids))
+	lookup := muids
+	// Each round looks up the manga the last round's were merged into
+	for i := 0; i <= scrape.MaxMergeChain && len(lookup) > 0; i++ {
+		found := make([]mangaState, 0, len(lookup))
+		if err := m.db.SelectContext(ctx, &found, query, lookup); err != nil {
+			logger.Errf(ctx, "Failed resolving muids with %s err: %s", query, ErrDetails(err))
+			return nil, errors.WithStack(err)
+		}
+		lookup = make(pq.Int64Array, 0)
+		for _, s := range found {
+			states[s.MUID] = s
+			if _, seen := states[s.MergedInto]; s.MergedInto > 0 && !seen {
+				lookup = append(lookup, int64(s.MergedInto))
+			}
+		}
+	}
+	resolved := make(map[int]int, len(muids))
+	for _, muid := range muids {
+		s, found := states[int(muid)]
+		if !found {
+			continue
+		}
+		for i := 0; i < scrape.MaxMergeChain && s.MergedInto > 0; i++ {
+			if s, found = states[s.MergedInto]; !found {
+				break
+			}
+		}
+		if found && !s.Retired {
+			resolved[int(muid)] = s.MUID
+		} else {
+			resolved[int(muid)] = 0
+		}
+	}
+	return resolved, nil
+}
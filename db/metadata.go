@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// MangaMetadata is everything known about a manga besides its titles and releases.
+type MangaMetadata struct {
+	MUID         int
+	DisplayTitle string `db:"display_title"`
+	Status       string
+	Type         string
+	Year         int
+	CoverURL     string `db:"cover_url"`
+	// CoverHash is the hash of the cached cover image, empty if the cover hasn't been cached
+	CoverHash     string   `db:"cover_hash"`
+	ThumbnailSize int      `db:"thumbnail_size"`
+	Genres        []string `db:"-"`
+	Categories    []string `db:"-"`
+	Authors       []string `db:"-"`
+	Artists       []string `db:"-"`
+}
+
+const (
+	authorRole = "author"
+	artistRole = "artist"
+)
+
+// replaceMangaMetadata replaces the genres, categories, authors and artists of each manga with the scraped ones.
+func (m *mangaStore) replaceMangaMetadata(ctx context.Context, manga []scrape.MangaInfo) error {
+	muids := make(pq.Int64Array, 0, len(manga))
+	genres := make(map[int][]string)
+	categories := make(map[int][]string)
+	people := make(map[int][]string)
+	for _, mi := range manga {
+		if mi.MUID < 1 {
+			continue
+		}
+		muids = append(muids, int64(mi.MUID))
+		genres[mi.MUID] = mi.Genres
+		categories[mi.MUID] = mi.Categories
+		for _, a := range mi.Authors {
+			people[mi.MUID] = append(people[mi.MUID], authorRole, a)
+		}
+		for _, a := range mi.Artists {
+			people[mi.MUID] = append(people[mi.MUID], artistRole, a)
+		}
+	}
+	if err := m.replaceMangaValues(ctx, "mangagenre", []string{"genre"}, muids, genres); err != nil {
+		return err
+	}
+	if err := m.replaceMangaValues(ctx, "mangacategory", []string{"category"}, muids, categories); err != nil {
+		return err
+	}
+	return m.replaceMangaValues(ctx, "mangaauthor", []string{"role", "name"}, muids, people)
+}
+
+// replaceMangaValues deletes the rows of table belonging to muids and inserts values in their place.
+// values holds the columns for each row flattened together, in the same order as columns.
+func (m *mangaStore) replaceMangaValues(ctx context.Context, table string, columns []string, muids pq.Int64Array, values map[int][]string) error {
+	deleteQuery := m.db.Rebind(fmt.Sprintf("DELETE FROM %s WHERE muid = ANY ?;", table))
+	if _, err := m.db.ExecContext(ctx, deleteQuery, muids); err != nil {
+		logger.Errf(ctx, "Failed deleting old %s with %s err: %s", table, deleteQuery, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	placeholder := " (?" + strings.Repeat(",?", len(columns)) + "),"
+	insertValues := ""
+	args := make([]interface{}, 0)
+	for muid, vals := range values {
+		seen := make(map[string]struct{})
+		for i := 0; i+len(columns) <= len(vals); i += len(columns) {
+			row := vals[i : i+len(columns)]
+			key := strings.Join(row, "\x00")
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			insertValues += placeholder
+			args = append(args, muid)
+			for _, v := range row {
+				args = append(args, v)
+			}
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	insertQuery := fmt.Sprintf("INSERT INTO %s (muid,%s) VALUES %s;", table, strings.Join(columns, ","), insertValues[:len(insertValues)-1])
+	insertQuery = m.db.Rebind(insertQuery)
+	if _, err := m.db.ExecContext(ctx, insertQuery, args...); err != nil {
+		logger.Errf(ctx, "Failed inserting %s with %s err: %s", table, insertQuery, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// FindMangaMetadata returns the metadata of each manga, keyed by muid.
+func (m *mangaStore) FindMangaMetadata(ctx context.Context, muids pq.Int64Array) (map[int]MangaMetadata, error) {
+	metadata := make(map[int]MangaMetadata, len(muids))
+	if len(muids) == 0 {
+		return metadata, nil
+	}
+	mangaQuery := `
+	SELECT m.muid, m.display_title, m.status, m."type", m.year, m.cover_url,
+		COALESCE(mc.hash, '') AS cover_hash, COALESCE(length(ci.thumbnail), 0) AS thumbnail_size
+	FROM manga m
+	LEFT JOIN mangacover mc ON m.muid = mc.muid
+	LEFT JOIN coverimage ci ON mc.hash = ci.hash
+	WHERE m.muid = ANY ?;
+	`
+	mangaQuery = m.db.Rebind(mangaQuery)
+	manga := make([]MangaMetadata, 0, len(muids))
+	if err := m.db.SelectContext(ctx, &manga, mangaQuery, muids); err != nil {
+		logger.Errf(ctx, "Failed getting manga metadata with %s err:%+v", mangaQuery, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	for _, mm := range manga {
+		metadata[mm.MUID] = mm
+	}
+
+	type muidValue struct {
+		MUID  int
+		Kind  string
+		Value string
+	}
+	valuesQuery := `
+	SELECT muid, 'genre' AS kind, genre AS value FROM mangagenre WHERE muid = ANY ?
+	UNION ALL
+	SELECT muid, 'category' AS kind, category AS value FROM mangacategory WHERE muid = ANY ?
+	UNION ALL
+	SELECT muid, role AS kind, name AS value FROM mangaauthor WHERE muid = ANY ?;
+	`
+	valuesQuery = m.db.Rebind(valuesQuery)
+	values := make([]muidValue, 0)
+	if err := m.db.SelectContext(ctx, &values, valuesQuery, muids, muids, muids); err != nil {
+		logger.Errf(ctx, "Failed getting manga metadata with %s err:%+v", valuesQuery, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	for _, v := range values {
+		mm, found := metadata[v.MUID]
+		if !found {
+			continue
+		}
+		switch v.Kind {
+		case "genre":
+			mm.Genres = append(mm.Genres, v.Value)
+		case "category":
+			mm.Categories = append(mm.Categories, v.Value)
+		case authorRole:
+			mm.Authors = append(mm.Authors, v.Value)
+		case artistRole:
+			mm.Artists = append(mm.Artists, v.Value)
+		}
+		metadata[v.MUID] = mm
+	}
+	return metadata, nil
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/pkg/errors"
+)
+
+// ClaimMUIDSource records that the muids in the db are source's ids, unless another source's already are.
+// The source whose ids they are is returned either way, so callers can refuse to mix in the ids of another.
+func (m *mangaStore) ClaimMUIDSource(ctx context.Context, source string) (string, error) {
+	query := `
+	INSERT INTO muidsource (id, source) VALUES (1, ?) ON CONFLICT (id) DO NOTHING;
+	`
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, source); err != nil {
+		logger.Errf(ctx, "Failed to claim muid source with %s err %s", query, ErrDetails(err))
+		return "", errors.WithStack(err)
+	}
+	return m.GetMUIDSource(ctx)
+}
+
+// GetMUIDSource returns the source whose ids the muids in the db are, or "" if no source has claimed the db yet.
+func (m *mangaStore) GetMUIDSource(ctx context.Context) (string, error) {
+	query := `
+	SELECT source FROM muidsource WHERE id = 1;
+	`
+	var owner string
+	if err := m.db.GetContext(ctx, &owner, query); err == sql.ErrNoRows {
+		return "", nil
+	} else if err != nil {
+		logger.Errf(ctx, "Failed to get muid source with %s err %s", query, ErrDetails(err))
+		return "", errors.WithStack(err)
+	}
+	return owner, nil
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/pkg/errors"
+)
+
+// GetLastPoll returns when source was last polled successfully, or the zero time if it never has been.
+func (m *mangaStore) GetLastPoll(ctx context.Context, source string) (time.Time, error) {
+	query := `
+	SELECT last_polled_at FROM pollstate WHERE source=?;
+	`
+	query = m.db.Rebind(query)
+	var lastPolled time.Time
+	if err := m.db.GetContext(ctx, &lastPolled, query, source); err == sql.ErrNoRows {
+		return time.Time{}, nil
+	} else if err != nil {
+		logger.Errf(ctx, "Failed to get last poll with %s err %s", query, ErrDetails(err))
+		return time.Time{}, errors.WithStack(err)
+	}
+	return lastPolled, nil
+}
+
+// SetLastPoll records that source was successfully polled at the given time.
+func (m *mangaStore) SetLastPoll(ctx context.Context, source string, at time.Time) error {
+	query := `
+	UPSERT INTO pollstate (source, last_polled_at) VALUES (?,?);
+	`
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, source, at); err != nil {
+		logger.Errf(ctx, "Failed to set last poll with %s err %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/pkg/errors"
+)
+
+// GetPopulateCheckpoint returns the muid populate-db should resume the range from start to end at.
+// Every muid in the range before it has been scraped or recorded as a failure. Ranges never populated before resume at start.
+func (m *mangaStore) GetPopulateCheckpoint(ctx context.Context, source string, start, end int) (int, error) {
+	query := `
+	SELECT next_muid FROM populatecheckpoint WHERE source=? AND start_muid=? AND end_muid=?;
+	`
+	query = m.db.Rebind(query)
+	var next int
+	if err := m.db.GetContext(ctx, &next, query, source, start, end); err == sql.ErrNoRows {
+		return start, nil
+	} else if err != nil {
+		logger.Errf(ctx, "Failed to get populate checkpoint with %s err %s", query, ErrDetails(err))
+		return 0, errors.WithStack(err)
+	}
+	return next, nil
+}
+
+// SetPopulateCheckpoint records that populate-db should resume the range from start to end at next.
+func (m *mangaStore) SetPopulateCheckpoint(ctx context.Context, source string, start, end, next int) error {
+	query := `
+	UPSERT INTO populatecheckpoint (source, start_muid, end_muid, next_muid, updated_at) VALUES (?,?,?,?,?);
+	`
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, source, start, end, next, time.Now().UTC()); err != nil {
+		logger.Errf(ctx, "Failed to set populate checkpoint with %s err %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// FindPopulateFailures returns the muids between start and end inclusive that failed to be scraped by source.
+func (m *mangaStore) FindPopulateFailures(ctx context.Context, source string, start, end int) ([]int, error) {
+	query := `
+	SELECT muid FROM populatefailure WHERE source=? AND muid BETWEEN ? AND ? ORDER BY muid;
+	`
+	query = m.db.Rebind(query)
+	muids := make([]int, 0)
+	if err := m.db.SelectContext(ctx, &muids, query, source, start, end); err != nil {
+		logger.Errf(ctx, "Failed to find populate failures with %s err %s", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	return muids, nil
+}
+
+// UpsertPopulateFailure records that scraping muid from source failed with scrapeErr, counting how many times it has.
+func (m *mangaStore) UpsertPopulateFailure(ctx context.Context, source string, muid int, scrapeErr error) error {
+	query := `
+	INSERT INTO populatefailure (source, muid, attempts, last_error, failed_at) VALUES (?,?,1,?,?)
+	ON CONFLICT (source, muid)
+	DO UPDATE SET attempts = populatefailure.attempts + 1, last_error = excluded.last_error, failed_at = excluded.failed_at;
+	`
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, source, muid, scrapeErr.Error(), time.Now().UTC()); err != nil {
+		logger.Errf(ctx, "Failed to upsert populate failure with %s err %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// DeletePopulateFailure forgets a failure once muid has been scraped from source successfully.
+func (m *mangaStore) DeletePopulateFailure(ctx context.Context, source string, muid int) error {
+	query := `
+	DELETE FROM populatefailure WHERE source=? AND muid=?;
+	`
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, source, muid); err != nil {
+		logger.Errf(ctx, "Failed to delete populate failure with %s err %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
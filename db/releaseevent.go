@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// ReleaseEventNew is logged the first time a release is stored
+const ReleaseEventNew = "new"
+
+// ReleaseEvent is an entry in the log of what happened to releases in the db, such as when each one first appeared.
+type ReleaseEvent struct {
+	ID          int64
+	ReleaseID   int64 `db:"release_id"`
+	MUID        int
+	Release     string
+	Translators string
+	Event       string
+	ReleasedAt  time.Time `db:"released_at"`
+	SeenAt      time.Time `db:"seen_at"`
+}
+
+// insertReleaseEvents logs a ReleaseEventNew for each of the freshly inserted releases in events as part of tx.
+func (m *mangaStore) insertReleaseEvents(ctx context.Context, tx *sqlx.Tx, events []ReleaseEvent, at time.Time) error {
+	if len(events) == 0 {
+		return nil
+	}
+	values := ""
+	args := make([]interface{}, 0, len(events)*7)
+	for _, e := range events {
+		values += " (?,?,?,?,?,?,?),"
+		args = append(args, e.ReleaseID, e.MUID, e.Release, e.Translators, ReleaseEventNew, e.ReleasedAt, at)
+	}
+	query := fmt.Sprintf("INSERT INTO releaseevent (release_id, muid, release, translators, event, released_at, seen_at) VALUES %s;", values[:len(values)-1])
+	query = tx.Rebind(query)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		logger.Errf(ctx, "Failed inserting release events with %s err: %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// FindReleaseEvents returns up to limit release events logged after the one with id afterID, oldest first.
+func (m *mangaStore) FindReleaseEvents(ctx context.Context, afterID int64, limit int) ([]ReleaseEvent, error) {
+	query := `
+	SELECT id, release_id, muid, release, translators, event, released_at, seen_at FROM releaseevent WHERE id > ? ORDER BY id ASC LIMIT ?;
+	`
+	query = m.db.Rebind(query)
+	events := make([]ReleaseEvent, 0, limit)
+	if err := m.db.SelectContext(ctx, &events, query, afterID, limit); err != nil {
+		logger.Errf(ctx, "Failed finding release events with %s err: %s", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	return events, nil
+}
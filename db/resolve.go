@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// How a release title was mapped to its series
+const (
+	ResolvedByAdmin  = "admin"
+	ResolvedBySearch = "search"
+)
+
+// UnresolvedRelease is a release whose series couldn't be found, waiting for an admin to map its title to one.
+type UnresolvedRelease struct {
+	Source      string
+	Title       string
+	Release     string
+	Translators string
+	Groups      []scrape.ScanlationGroup
+	ReleasedAt  time.Time
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+	SeenCount   int
+}
+
+// MangaRelease returns the release as if it had linked to muid.
+func (u UnresolvedRelease) MangaRelease(muid int) scrape.MangaRelease {
+	return scrape.MangaRelease{
+		MUID:        muid,
+		Title:       u.Title,
+		Release:     u.Release,
+		Translators: u.Translators,
+		Groups:      u.Groups,
+		CreatedAt:   u.FirstSeenAt,
+		ReleasedAt:  u.ReleasedAt,
+	}
+}
+
+// FindReleaseTitleMappings returns the muid each of titles from source has been mapped to, leaving out the unmapped ones.
+func (m *mangaStore) FindReleaseTitleMappings(ctx context.Context, source string, titles []string) (map[string]int, error) {
+	query := `
+	SELECT title, muid FROM releasetitlemap WHERE source=? AND title = ANY ?;
+	`
+	query = m.db.Rebind(query)
+	rows := make([]struct {
+		Title string
+		MUID  int
+	}, 0, len(titles))
+	if err := m.db.SelectContext(ctx, &rows, query, source, pq.StringArray(titles)); err != nil {
+		logger.Errf(ctx, "Failed to find release title mappings with %s err %s", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	mappings := make(map[string]int, len(rows))
+	for _, r := range rows {
+		mappings[r.Title] = r.MUID
+	}
+	return mappings, nil
+}
+
+// UpsertReleaseTitleMapping maps the releases from source titled title to muid from now on, noting who decided on it.
+func (m *mangaStore) UpsertReleaseTitleMapping(ctx context.Context, source, title string, muid int, resolvedBy string) error {
+	query := `
+	UPSERT INTO releasetitlemap (source, title, muid, resolved_by, created_at) VALUES (?,?,?,?,?);
+	`
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, source, title, muid, resolvedBy, time.Now().UTC()); err != nil {
+		logger.Errf(ctx, "Failed to upsert release title mapping with %s err %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// QueueUnresolvedReleases adds releases to the queue an admin maps manually, counting how often the ones already in it are seen again.
+func (m *mangaStore) QueueUnresolvedReleases(ctx context.Context, source string, releases []scrape.MangaRelease) error {
+	query := `
+	INSERT INTO unresolvedrelease (source, title, release, translators, groups, released_at, first_seen_at, last_seen_at) VALUES (?,?,?,?,?,?,?,?)
+	ON CONFLICT (source, title, release, translators)
+	DO UPDATE SET last_seen_at = excluded.last_seen_at, seen_count = unresolvedrelease.seen_count + 1;
+	`
+	query = m.db.Rebind(query)
+	now := time.Now().UTC()
+	for _, r := range releases {
+		groups, err := json.Marshal(r.Groups)
+		if err != nil {
+			return errors.Wrap(err, "Failed encoding release groups")
+		}
+		releasedAt := r.ReleasedAt
+		if releasedAt.IsZero() {
+			releasedAt = r.CreatedAt
+		}
+		if _, err := m.db.ExecContext(ctx, query, source, r.Title, r.Release, r.Translators, string(groups), releasedAt, now, now); err != nil {
+			logger.Errf(ctx, "Failed to queue unresolved release with %s err %s", query, ErrDetails(err))
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// FindUnresolvedReleases returns the queued releases from source ordered by title.
+// Only the releases titled title are returned if it isn't empty.
+func (m *mangaStore) FindUnresolvedReleases(ctx context.Context, source, title string) ([]UnresolvedRelease, error) {
+	query := `
+	SELECT source, title, release, translators, groups, released_at, first_seen_at, last_seen_at, seen_count FROM unresolvedrelease
+	WHERE source=? AND (?='' OR title=?)
+	ORDER BY title, released_at;
+	`
+	query = m.db.Rebind(query)
+	rows := make([]struct {
+		Source      string
+		Title       string
+		Release     string
+		Translators string
+		Groups      []byte
+		ReleasedAt  time.Time `db:"released_at"`
+		FirstSeenAt time.Time `db:"first_seen_at"`
+		LastSeenAt  time.Time `db:"last_seen_at"`
+		SeenCount   int       `db:"seen_count"`
+	}, 0)
+	if err := m.db.SelectContext(ctx, &rows, query, source, title, title); err != nil {
+		logger.Errf(ctx, "Failed to find unresolved releases with %s err %s", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	unresolved := make([]UnresolvedRelease, 0, len(rows))
+	for _, r := range rows {
+		u := UnresolvedRelease{
+			Source:      r.Source,
+			Title:       r.Title,
+			Release:     r.Release,
+			Translators: r.Translators,
+			ReleasedAt:  r.ReleasedAt,
+			FirstSeenAt: r.FirstSeenAt,
+			LastSeenAt:  r.LastSeenAt,
+			SeenCount:   r.SeenCount,
+		}
+		if err := json.Unmarshal(r.Groups, &u.Groups); err != nil {
+			return nil, errors.Wrap(err, "Failed decoding release groups")
+		}
+		unresolved = append(unresolved, u)
+	}
+	return unresolved, nil
+}
+
+// DeleteUnresolvedReleases removes every queued release from source titled title.
+func (m *mangaStore) DeleteUnresolvedReleases(ctx context.Context, source, title string) error {
+	query := `
+	DELETE FROM unresolvedrelease WHERE source=? AND title=?;
+	`
+	query = m.db.Rebind(query)
+	if _, err := m.db.ExecContext(ctx, query, source, title); err != nil {
+		logger.Errf(ctx, "Failed to delete unresolved releases with %s err %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
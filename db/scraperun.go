@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// scrapeRunRow is a scrape.ScrapeRun as stored in scrape_runs.
+type scrapeRunRow struct {
+	Source     string
+	StartedAt  time.Time `db:"started_at"`
+	DurationMS int64     `db:"duration_ms"`
+	Releases   int
+	WithMUID   int `db:"releases_with_muid"`
+	Error      string
+	Anomalies  pq.StringArray
+}
+
+// InsertScrapeRun records run in scrape_runs.
+func (m *mangaStore) InsertScrapeRun(ctx context.Context, run scrape.ScrapeRun) error {
+	query := `
+	INSERT INTO scrape_runs (source, started_at, duration_ms, releases, releases_with_muid, error, anomalies) VALUES (?,?,?,?,?,?,?);
+	`
+	query = m.db.Rebind(query)
+	anomalies := pq.StringArray(run.Anomalies)
+	if anomalies == nil {
+		anomalies = pq.StringArray{}
+	}
+	if _, err := m.db.ExecContext(ctx, query, run.Source, run.StartedAt, int64(run.Duration/time.Millisecond),
+		run.Releases, run.WithMUID, run.Error, anomalies); err != nil {
+		logger.Errf(ctx, "Failed to insert scrape run with %s err %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// FindScrapeRuns returns up to limit of the most recent runs of source, newest first.
+func (m *mangaStore) FindScrapeRuns(ctx context.Context, source string, limit int) ([]scrape.ScrapeRun, error) {
+	query := `
+	SELECT source, started_at, duration_ms, releases, releases_with_muid, error, anomalies FROM scrape_runs
+	WHERE source=? ORDER BY started_at DESC LIMIT ?;
+	`
+	query = m.db.Rebind(query)
+	rows := make([]scrapeRunRow, 0, limit)
+	if err := m.db.SelectContext(ctx, &rows, query, source, limit); err != nil {
+		logger.Errf(ctx, "Failed to find scrape runs with %s err %s", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	runs := make([]scrape.ScrapeRun, 0, len(rows))
+	for _, r := range rows {
+		runs = append(runs, scrape.ScrapeRun{
+			Source:    r.Source,
+			StartedAt: r.StartedAt,
+			Duration:  time.Duration(r.DurationMS) * time.Millisecond,
+			Releases:  r.Releases,
+			WithMUID:  r.WithMUID,
+			Error:     r.Error,
+			Anomalies: r.Anomalies,
+		})
+	}
+	return runs, nil
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/danlock/feedgen/scrape"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// PageSnapshot is a stored scrape.Snapshot. IDs increase with every fetch, so they can be used to page through snapshots.
+type PageSnapshot struct {
+	ID int64
+	scrape.Snapshot
+}
+
+// SaveSnapshot stores the page in snap gzipped under the sha256 of its contents, so a page fetched many times unchanged is only stored once,
+// and records where and when it was fetched.
+func (m *mangaStore) SaveSnapshot(ctx context.Context, snap scrape.Snapshot) error {
+	sum := sha256.Sum256(snap.Body)
+	hash := hex.EncodeToString(sum[:])
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(snap.Body); err != nil {
+		return errors.Wrap(err, "Failed compressing snapshot")
+	}
+	if err := zw.Close(); err != nil {
+		return errors.Wrap(err, "Failed compressing snapshot")
+	}
+	pageQuery := `
+	INSERT INTO pagesnapshot (hash, body, size) VALUES (?,?,?)
+	ON CONFLICT (hash) DO NOTHING;
+	`
+	pageQuery = m.db.Rebind(pageQuery)
+	if _, err := m.db.ExecContext(ctx, pageQuery, hash, compressed.Bytes(), len(snap.Body)); err != nil {
+		logger.Errf(ctx, "Failed to insert page snapshot with %s err %s", pageQuery, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	var day pq.NullTime
+	if !snap.Day.IsZero() {
+		day = pq.NullTime{Time: snap.Day, Valid: true}
+	}
+	fetchQuery := `
+	INSERT INTO pagefetch (source, kind, url, muid, day, hash, fetched_at) VALUES (?,?,?,?,?,?,?);
+	`
+	fetchQuery = m.db.Rebind(fetchQuery)
+	if _, err := m.db.ExecContext(ctx, fetchQuery, snap.Source, string(snap.Kind), snap.URL, snap.MUID, day, hash, snap.FetchedAt); err != nil {
+		logger.Errf(ctx, "Failed to insert page fetch with %s err %s", fetchQuery, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// FindSnapshots returns up to limit snapshots of the given kinds that source fetched between from and to, starting after the one with id afterID.
+func (m *mangaStore) FindSnapshots(ctx context.Context, source string, kinds []scrape.SnapshotKind, from, to time.Time, afterID int64, limit int) ([]PageSnapshot, error) {
+	return m.findSnapshots(ctx, "", source, kinds, from, to, afterID, limit)
+}
+
+// FindLatestSeriesSnapshots is like FindSnapshots for series pages, but skips every snapshot of a series that isn't its newest one,
+// so parsing them again never overwrites a series with an older version of it.
+func (m *mangaStore) FindLatestSeriesSnapshots(ctx context.Context, source string, from, to time.Time, afterID int64, limit int) ([]PageSnapshot, error) {
+	newest := `AND NOT EXISTS (SELECT 1 FROM pagefetch newer WHERE newer.source = f.source AND newer.kind = f.kind AND newer.muid = f.muid AND newer.fetched_at > f.fetched_at)`
+	return m.findSnapshots(ctx, newest, source, []scrape.SnapshotKind{scrape.SnapshotSeries}, from, to, afterID, limit)
+}
+
+func (m *mangaStore) findSnapshots(ctx context.Context, extraWhere, source string, kinds []scrape.SnapshotKind, from, to time.Time, afterID int64, limit int) ([]PageSnapshot, error) {
+	query := `
+	SELECT f.id, f.source, f.kind, f.url, f.muid, f.day, f.fetched_at, s.body FROM pagefetch f
+	JOIN pagesnapshot s ON s.hash = f.hash
+	WHERE f.source = ? AND f.kind = ANY ? AND f.fetched_at BETWEEN ? AND ? AND f.id > ? %s
+	ORDER BY f.id ASC LIMIT ?;
+	`
+	query = m.db.Rebind(fmt.Sprintf(query, extraWhere))
+	kindStrs := make(pq.StringArray, 0, len(kinds))
+	for _, k := range kinds {
+		kindStrs = append(kindStrs, string(k))
+	}
+	rows := make([]struct {
+		ID        int64
+		Source    string
+		Kind      string
+		URL       string
+		MUID      int
+		Day       pq.NullTime
+		FetchedAt time.Time `db:"fetched_at"`
+		Body      []byte
+	}, 0, limit)
+	if err := m.db.SelectContext(ctx, &rows, query, source, kindStrs, from, to, afterID, limit); err != nil {
+		logger.Errf(ctx, "Failed to find page snapshots with %s err %s", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	snapshots := make([]PageSnapshot, 0, len(rows))
+	for _, r := range rows {
+		zr, err := gzip.NewReader(bytes.NewReader(r.Body))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed decompressing snapshot %d", r.ID)
+		}
+		body, err := ioutil.ReadAll(zr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed decompressing snapshot %d", r.ID)
+		}
+		snapshots = append(snapshots, PageSnapshot{ID: r.ID, Snapshot: scrape.Snapshot{
+			Source:    r.Source,
+			Kind:      scrape.SnapshotKind(r.Kind),
+			URL:       r.URL,
+			MUID:      r.MUID,
+			Day:       r.Day.Time,
+			Body:      body,
+			FetchedAt: r.FetchedAt,
+		}})
+	}
+	return snapshots, nil
+}
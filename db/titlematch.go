@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"golang.org/x/text/unicode/norm"
+)
+
+// FoldTitle reduces a title to the form stored in mangatitle.folded_title, so titles that only differ in
+// Unicode representation, case, punctuation or spacing match. "Kaguya-sama: Love is War" folds to "kaguya sama love is war".
+func FoldTitle(title string) string {
+	title = strings.ToLower(norm.NFKC.String(title))
+	title = strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			return ' '
+		}
+		return r
+	}, title)
+	return strings.Join(strings.Fields(title), " ")
+}
+
+// TitleCandidate is a manga whose title resembles a title that didn't match any.
+type TitleCandidate struct {
+	MUID         int
+	Title        string
+	DisplayTitle string `db:"display_title"`
+	// Score is how alike the titles are, from 0 to 1
+	Score float64
+}
+
+// foldedTitle is a title as stored in mangatitle along with its FoldTitle form.
+type foldedTitle struct {
+	MUID   int
+	Title  string
+	Folded string `db:"folded_title"`
+}
+
+// FindMangaByFoldedTitles finds the manga with a title that folds to one of folded, as returned by FoldTitle.
+// OriginalTitle is set to the folded title that matched.
+func (m *mangaStore) FindMangaByFoldedTitles(ctx context.Context, folded []string) ([]MangaTitle, error) {
+	query := `
+	SELECT DISTINCT manga.muid, mangatitle.folded_title AS title, manga.display_title
+	FROM mangatitle
+	INNER JOIN manga ON manga.muid=mangatitle.muid
+	WHERE mangatitle.folded_title = ANY ? AND mangatitle.retired_at IS NULL AND manga.retired_at IS NULL;
+	`
+	query = m.db.Rebind(query)
+	manga := make([]MangaTitle, 0, len(folded))
+	if err := m.db.SelectContext(ctx, &manga, query, pq.StringArray(folded)); err != nil {
+		logger.Errf(ctx, "Failed to find manga by folded titles with %s err %s", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	return manga, nil
+}
+
+// FindTitleCandidates returns up to limit manga with a title resembling title, most alike first.
+// Every title sharing a trigram with title is ranked in the db by the share of their trigrams they have in common,
+// looked up through mangatitletrigram, and only the most alike title of each manga is kept.
+func (m *mangaStore) FindTitleCandidates(ctx context.Context, title string, limit int) ([]TitleCandidate, error) {
+	grams := trigrams(FoldTitle(title))
+	if len(grams) == 0 {
+		return nil, nil
+	}
+	titleGrams := make(pq.StringArray, 0, len(grams))
+	for g := range grams {
+		titleGrams = append(titleGrams, g)
+	}
+	query := `
+	SELECT muid, title, display_title, score FROM (
+		SELECT muid, title, display_title, score,
+			row_number() OVER (PARTITION BY muid ORDER BY score DESC, title) AS title_rank
+		FROM (
+			SELECT mangatitle.muid, mangatitle.title, manga.display_title,
+				count(*)::FLOAT / (?::INT + mangatitle.trigram_count - count(*))::FLOAT AS score
+			FROM mangatitletrigram
+			INNER JOIN mangatitle ON mangatitle.muid=mangatitletrigram.muid AND mangatitle.title=mangatitletrigram.title
+			INNER JOIN manga ON manga.muid=mangatitle.muid
+			WHERE mangatitletrigram.trigram = ANY ? AND mangatitle.retired_at IS NULL AND manga.retired_at IS NULL
+			GROUP BY mangatitle.muid, mangatitle.title, manga.display_title, mangatitle.trigram_count
+		) scored
+	) ranked
+	WHERE title_rank = 1
+	ORDER BY score DESC, muid
+	LIMIT ?;
+	`
+	query = m.db.Rebind(query)
+	candidates := make([]TitleCandidate, 0, limit)
+	if err := m.db.SelectContext(ctx, &candidates, query, len(titleGrams), titleGrams, limit); err != nil {
+		logger.Errf(ctx, "Failed to find title candidates with %s err %s", query, ErrDetails(err))
+		return nil, errors.WithStack(err)
+	}
+	return candidates, nil
+}
+
+// FoldMissingTitles fills in folded_title and the trigrams of up to limit titles stored before they existed, returning how many were folded.
+func (m *mangaStore) FoldMissingTitles(ctx context.Context, limit int) (int, error) {
+	query := `
+	SELECT muid, title FROM mangatitle WHERE folded_title IS NULL OR trigram_count IS NULL LIMIT ?;
+	`
+	query = m.db.Rebind(query)
+	titles := make([]foldedTitle, 0, limit)
+	if err := m.db.SelectContext(ctx, &titles, query, limit); err != nil {
+		logger.Errf(ctx, "Failed to find unfolded titles with %s err %s", query, ErrDetails(err))
+		return 0, errors.WithStack(err)
+	}
+	for i := range titles {
+		titles[i].Folded = FoldTitle(titles[i].Title)
+	}
+	// The trigrams go in first, so titles that fail halfway are still picked up by the next call
+	if err := m.upsertTitleTrigrams(ctx, titles); err != nil {
+		return 0, err
+	}
+	update := m.db.Rebind(`UPDATE mangatitle SET folded_title = ?, trigram_count = ? WHERE muid = ? AND title = ?;`)
+	for _, t := range titles {
+		if _, err := m.db.ExecContext(ctx, update, t.Folded, len(trigrams(t.Folded)), t.MUID, t.Title); err != nil {
+			logger.Errf(ctx, "Failed to fold title with %s err %s", update, ErrDetails(err))
+			return 0, errors.WithStack(err)
+		}
+	}
+	return len(titles), nil
+}
+
+// upsertTitleTrigrams indexes the trigrams of the folded titles in mangatitletrigram, for FindTitleCandidates.
+// The titles must already be in mangatitle.
+func (m *mangaStore) upsertTitleTrigrams(ctx context.Context, titles []foldedTitle) error {
+	values := ""
+	args := make([]interface{}, 0)
+	for _, t := range titles {
+		for g := range trigrams(t.Folded) {
+			values += " (?,?,?),"
+			args = append(args, g, t.Title, t.MUID)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	query := m.db.Rebind(fmt.Sprintf("UPSERT INTO mangatitletrigram (trigram,title,muid) VALUES %s;", values[:len(values)-1]))
+	if _, err := m.db.ExecContext(ctx, query, args...); err != nil {
+		logger.Errf(ctx, "Failed upserting title trigrams with %s err %s", query, ErrDetails(err))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// trigrams returns the set of three letter sequences in each word of s, padded the way pg_trgm pads them.
+func trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, w := range strings.Fields(s) {
+		r := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			set[string(r[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
@@ -17,39 +17,115 @@ import (
 // swagger:model FeedgenMangaRequestBody
 type FeedgenMangaRequestBody struct {
 
+	// Create the feed from the titles and muids that were found, listing the rest in the response instead of refusing the whole request
+	AllowPartial bool `json:"allowPartial,omitempty"`
+
+	// Description of an editable feed
+	// Max Length: 1024
+	Description string `json:"description,omitempty"`
+
+	// Create a feed whose titles can be changed later with the returned owner token, keeping its URL
+	Editable bool `json:"editable,omitempty"`
+
+	// MangaUpdates ids of manga to subscribe to, which also pick the manga meant by titles matching more than one. Manga missing from the database are scraped
+	// Max Items: 2048
+	Muids []int64 `json:"muids"`
+
+	// Name of an editable feed, used as the title of the feed
+	// Max Length: 256
+	Name string `json:"name,omitempty"`
+
+	// Create a feed with a random 128-bit identifier instead of one derived from its titles, so nobody else can find it
+	Private bool `json:"private,omitempty"`
+
 	// List of manga titles to subscribe to
-	// Required: true
 	// Max Items: 2048
-	// Min Items: 1
 	Titles []string `json:"titles"`
+
+	// MangaUpdates series URLs of manga to subscribe to, read like muids. Titles that are URLs are read the same way
+	// Max Items: 2048
+	Urls []string `json:"urls"`
 }
 
 // Validate validates this feedgen manga request body
 func (m *FeedgenMangaRequestBody) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateDescription(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMuids(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateName(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateTitles(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateUrls(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
-func (m *FeedgenMangaRequestBody) validateTitles(formats strfmt.Registry) error {
+func (m *FeedgenMangaRequestBody) validateDescription(formats strfmt.Registry) error {
 
-	if err := validate.Required("titles", "body", m.Titles); err != nil {
+	if swag.IsZero(m.Description) { // not required
+		return nil
+	}
+
+	if err := validate.MaxLength("description", "body", string(m.Description), 1024); err != nil {
 		return err
 	}
 
-	iTitlesSize := int64(len(m.Titles))
+	return nil
+}
+
+func (m *FeedgenMangaRequestBody) validateMuids(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Muids) { // not required
+		return nil
+	}
 
-	if err := validate.MinItems("titles", "body", iTitlesSize, 1); err != nil {
+	iMuidsSize := int64(len(m.Muids))
+
+	if err := validate.MaxItems("muids", "body", iMuidsSize, 2048); err != nil {
 		return err
 	}
 
+	return nil
+}
+
+func (m *FeedgenMangaRequestBody) validateName(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Name) { // not required
+		return nil
+	}
+
+	if err := validate.MaxLength("name", "body", string(m.Name), 256); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *FeedgenMangaRequestBody) validateTitles(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Titles) { // not required
+		return nil
+	}
+
+	iTitlesSize := int64(len(m.Titles))
+
 	if err := validate.MaxItems("titles", "body", iTitlesSize, 2048); err != nil {
 		return err
 	}
@@ -57,6 +133,21 @@ func (m *FeedgenMangaRequestBody) validateTitles(formats strfmt.Registry) error
 	return nil
 }
 
+func (m *FeedgenMangaRequestBody) validateUrls(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Urls) { // not required
+		return nil
+	}
+
+	iUrlsSize := int64(len(m.Urls))
+
+	if err := validate.MaxItems("urls", "body", iUrlsSize, 2048); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *FeedgenMangaRequestBody) MarshalBinary() ([]byte, error) {
 	if m == nil {
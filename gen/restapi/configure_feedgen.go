@@ -37,11 +37,41 @@ func configureAPI(api *operations.FeedgenAPI) http.Handler {
 
 	api.XMLProducer = runtime.XMLProducer()
 
+	if api.FeedgenDeleteMangaHandler == nil {
+		api.FeedgenDeleteMangaHandler = operations.FeedgenDeleteMangaHandlerFunc(func(params operations.FeedgenDeleteMangaParams) middleware.Responder {
+			return middleware.NotImplemented("operation .FeedgenDeleteManga has not yet been implemented")
+		})
+	}
+	if api.FeedgenImportMangaHandler == nil {
+		api.FeedgenImportMangaHandler = operations.FeedgenImportMangaHandlerFunc(func(params operations.FeedgenImportMangaParams) middleware.Responder {
+			return middleware.NotImplemented("operation .FeedgenImportManga has not yet been implemented")
+		})
+	}
 	if api.FeedgenMangaHandler == nil {
 		api.FeedgenMangaHandler = operations.FeedgenMangaHandlerFunc(func(params operations.FeedgenMangaParams) middleware.Responder {
 			return middleware.NotImplemented("operation .FeedgenManga has not yet been implemented")
 		})
 	}
+	if api.FeedgenMangaCoverHandler == nil {
+		api.FeedgenMangaCoverHandler = operations.FeedgenMangaCoverHandlerFunc(func(params operations.FeedgenMangaCoverParams) middleware.Responder {
+			return middleware.NotImplemented("operation .FeedgenMangaCover has not yet been implemented")
+		})
+	}
+	if api.FeedgenPatchMangaHandler == nil {
+		api.FeedgenPatchMangaHandler = operations.FeedgenPatchMangaHandlerFunc(func(params operations.FeedgenPatchMangaParams) middleware.Responder {
+			return middleware.NotImplemented("operation .FeedgenPatchManga has not yet been implemented")
+		})
+	}
+	if api.FeedgenResolveMangaHandler == nil {
+		api.FeedgenResolveMangaHandler = operations.FeedgenResolveMangaHandlerFunc(func(params operations.FeedgenResolveMangaParams) middleware.Responder {
+			return middleware.NotImplemented("operation .FeedgenResolveManga has not yet been implemented")
+		})
+	}
+	if api.FeedgenUpdateMangaHandler == nil {
+		api.FeedgenUpdateMangaHandler = operations.FeedgenUpdateMangaHandlerFunc(func(params operations.FeedgenUpdateMangaParams) middleware.Responder {
+			return middleware.NotImplemented("operation .FeedgenUpdateManga has not yet been implemented")
+		})
+	}
 	if api.FeedgenViewMangaHandler == nil {
 		api.FeedgenViewMangaHandler = operations.FeedgenViewMangaHandlerFunc(func(params operations.FeedgenViewMangaParams) middleware.Responder {
 			return middleware.NotImplemented("operation .FeedgenViewManga has not yet been implemented")
@@ -37,7 +37,7 @@ func init() {
   "paths": {
     "/api/feed/manga": {
       "post": {
-        "description": "Creates a URL containing the current feed for the requested manga titles",
+        "description": "Creates a URL containing the current feed for the requested manga titles, MangaUpdates ids and MangaUpdates series URLs.\nEditable feeds are created with a random identifier and returned along with the secret owner token needed to change them.\nPrivate feeds are also created with a random identifier, so their URL can't be worked out from their titles.\nTitles matching more than one manga are refused unless muids picks which of them is meant, see /api/manga/resolve.\nWith allowPartial, the feed is created from whatever was found as long as something was, and the titles left out are listed alongside it.\n",
         "summary": "Create feed from manga titles",
         "operationId": "feedgen#Manga",
         "parameters": [
@@ -46,9 +46,6 @@ func init() {
             "in": "body",
             "required": true,
             "schema": {
-              "required": [
-                "titles"
-              ],
               "$ref": "#/definitions/FeedgenMangaRequestBody"
             }
           }
@@ -60,8 +57,72 @@ func init() {
               "type": "string"
             }
           },
+          "201": {
+            "description": "Created response, for editable, private or partial feeds.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenMangaFeed"
+            }
+          },
+          "400": {
+            "description": "Bad Request response, if no titles, muids or urls are given."
+          },
           "404": {
-            "description": "Not Found response."
+            "description": "Not Found response, listing the titles that matched no manga with the manga they most resemble.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenTitlesNotFound"
+            }
+          },
+          "409": {
+            "description": "Conflict response, listing the titles that matched more than one manga with all of them.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error response."
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      }
+    },
+    "/api/feed/manga/import": {
+      "post": {
+        "description": "Creates a feed of the manga on a reading list exported from MyAnimeList, AniList or Kitsu.\nEntries are matched by their MangaUpdates cross references, then by every one of their titles, and the ones that couldn't be matched are listed alongside the feed.\n",
+        "summary": "Create feed from an exported reading list",
+        "operationId": "feedgen#importManga",
+        "parameters": [
+          {
+            "name": "ImportRequestBody",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FeedgenImportRequestBody"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Created response.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenMangaFeed"
+            }
+          },
+          "400": {
+            "description": "Bad Request response, if the export can't be read or holds too many manga."
+          },
+          "404": {
+            "description": "Not Found response, if no entry matched a manga, listing them with the manga they most resemble.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenTitlesNotFound"
+            }
+          },
+          "409": {
+            "description": "Conflict response, if every entry that matched a manga matched more than one, listing them with all of them.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveResponse"
+            }
           },
           "500": {
             "description": "Internal Server Error response."
@@ -100,6 +161,85 @@ func init() {
             "description": "RSS, Atom, or JSON Feed",
             "name": "feedType",
             "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "integer",
+              "format": "int64"
+            },
+            "collectionFormat": "csv",
+            "description": "Only include releases translated by at least one of these scanlation group ids",
+            "name": "groups",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "integer",
+              "format": "int64"
+            },
+            "collectionFormat": "csv",
+            "description": "Exclude releases translated by any of these scanlation group ids",
+            "name": "excludeGroups",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "Only include releases of manga with at least one of these genres",
+            "name": "genres",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "Exclude releases of manga with any of these genres",
+            "name": "excludeGenres",
+            "in": "query"
+          },
+          {
+            "maximum": 500,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 50,
+            "description": "Most releases in each page of the feed, newest first",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only include releases released at or after this time",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Only include the latest releases of each series, up to this many",
+            "name": "per_series",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Cursor of the release to return the releases older than, linked from the newer page as described by RFC 5005",
+            "name": "before",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Cursor of the release to return the releases newer than, linked from the older page as described by RFC 5005",
+            "name": "after",
+            "in": "query"
           }
         ],
         "responses": {
@@ -109,9 +249,15 @@ func init() {
               "type": "string"
             }
           },
+          "400": {
+            "description": "Bad Request response, if before or after isn't a cursor from a link of the feed."
+          },
           "404": {
             "description": "Not Found response."
           },
+          "409": {
+            "description": "Conflict response, if the feed's legacy link was given out for two sets of manga. Links to the feed of each set."
+          },
           "500": {
             "description": "Internal Server Error response."
           },
@@ -119,15 +265,11 @@ func init() {
             "description": "Bad Gateway response."
           }
         }
-      }
-    },
-    "/api/feed/manga/{hash}/titles/": {
-      "get": {
-        "produces": [
-          "application/json"
-        ],
-        "summary": "Get manga titles inside feed",
-        "operationId": "feedgen#viewMangaTitles",
+      },
+      "put": {
+        "description": "Replaces the titles, name and description of an editable feed, keeping its URL.",
+        "summary": "Replace the titles of an editable feed",
+        "operationId": "feedgen#updateManga",
         "parameters": [
           {
             "type": "string",
@@ -135,25 +277,47 @@ func init() {
             "name": "hash",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "Owner token returned when the editable feed was created",
+            "name": "X-Owner-Token",
+            "in": "header",
+            "required": true
+          },
+          {
+            "name": "MangaRequestBody",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FeedgenMangaRequestBody"
+            }
           }
         ],
         "responses": {
           "200": {
             "description": "OK response.",
             "schema": {
-              "type": "object",
-              "properties": {
-                "titles": {
-                  "type": "array",
-                  "items": {
-                    "type": "string"
-                  }
-                }
-              }
+              "$ref": "#/definitions/FeedgenMangaFeed"
             }
           },
+          "400": {
+            "description": "Bad Request response, if no titles, muids or urls are given."
+          },
+          "403": {
+            "description": "Forbidden response, if the feed isn't editable or the owner token is wrong."
+          },
           "404": {
-            "description": "Not Found response."
+            "description": "Not Found response, listing the titles that matched no manga with the manga they most resemble if the feed was found.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenTitlesNotFound"
+            }
+          },
+          "409": {
+            "description": "Conflict response, listing the titles that matched more than one manga with all of them.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveResponse"
+            }
           },
           "500": {
             "description": "Internal Server Error response."
@@ -162,104 +326,45 @@ func init() {
             "description": "Bad Gateway response."
           }
         }
-      }
-    }
-  },
-  "definitions": {
-    "FeedgenMangaRequestBody": {
-      "type": "object",
-      "title": "FeedgenMangaRequestBody",
-      "required": [
-        "titles"
-      ],
-      "properties": {
-        "titles": {
-          "description": "List of manga titles to subscribe to",
-          "type": "array",
-          "maxItems": 2048,
-          "minItems": 1,
-          "items": {
-            "type": "string",
-            "example": "Oyasumi Punpun"
-          },
-          "example": [
-            "Oyasumi Punpun",
-            "Berserk"
-          ]
-        }
       },
-      "example": {
-        "titles": [
-          "Oyasumi Punpun"
-        ]
-      }
-    }
-  }
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json",
-    "application/xml"
-  ],
-  "produces": [
-    "application/json",
-    "application/xml"
-  ],
-  "schemes": [
-    "http"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "description": "Generates update feeds for different types of media",
-    "title": "Feed Generator"
-  },
-  "paths": {
-    "/api/feed/manga": {
-      "post": {
-        "description": "Creates a URL containing the current feed for the requested manga titles",
-        "summary": "Create feed from manga titles",
-        "operationId": "feedgen#Manga",
+      "delete": {
+        "summary": "Delete an editable feed",
+        "operationId": "feedgen#deleteManga",
         "parameters": [
           {
-            "name": "MangaRequestBody",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "required": [
-                "titles"
-              ],
-              "$ref": "#/definitions/FeedgenMangaRequestBody"
-            }
+            "type": "string",
+            "description": "Identifier of previously created manga feed",
+            "name": "hash",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Owner token returned when the editable feed was created",
+            "name": "X-Owner-Token",
+            "in": "header",
+            "required": true
           }
         ],
         "responses": {
-          "200": {
-            "description": "OK response.",
-            "schema": {
-              "type": "string"
-            }
+          "204": {
+            "description": "No Content response."
+          },
+          "403": {
+            "description": "Forbidden response, if the feed isn't editable or the owner token is wrong."
           },
           "404": {
             "description": "Not Found response."
           },
-          "500": {
-            "description": "Internal Server Error response."
-          },
           "502": {
             "description": "Bad Gateway response."
           }
         }
-      }
-    },
-    "/api/feed/manga/{hash}": {
-      "get": {
-        "description": "Returns an RSS/Atom/JSON Feed of the manga titles.",
-        "produces": [
-          "application/xml",
-          "application/json"
-        ],
-        "summary": "Get feed of manga updates",
-        "operationId": "feedgen#viewManga",
+      },
+      "patch": {
+        "description": "Adds and removes titles of an editable feed and changes its name or description, keeping its URL.",
+        "summary": "Add or remove titles of an editable feed",
+        "operationId": "feedgen#patchManga",
         "parameters": [
           {
             "type": "string",
@@ -269,27 +374,42 @@ func init() {
             "required": true
           },
           {
-            "enum": [
-              "rss",
-              "atom",
-              "json"
-            ],
             "type": "string",
-            "default": "atom",
-            "description": "RSS, Atom, or JSON Feed",
-            "name": "feedType",
-            "in": "query"
+            "description": "Owner token returned when the editable feed was created",
+            "name": "X-Owner-Token",
+            "in": "header",
+            "required": true
+          },
+          {
+            "name": "MangaPatchBody",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FeedgenMangaPatchBody"
+            }
           }
         ],
         "responses": {
           "200": {
             "description": "OK response.",
             "schema": {
-              "type": "string"
+              "$ref": "#/definitions/FeedgenMangaFeed"
             }
           },
+          "403": {
+            "description": "Forbidden response, if the feed isn't editable or the owner token is wrong."
+          },
           "404": {
-            "description": "Not Found response."
+            "description": "Not Found response, listing the titles that matched no manga with the manga they most resemble if the feed was found.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenTitlesNotFound"
+            }
+          },
+          "409": {
+            "description": "Conflict response, listing the titles that matched more than one manga with all of them.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveResponse"
+            }
           },
           "500": {
             "description": "Internal Server Error response."
@@ -334,6 +454,9 @@ func init() {
           "404": {
             "description": "Not Found response."
           },
+          "409": {
+            "description": "Conflict response, if the feed's legacy link was given out for two sets of manga. Links to the feed of each set."
+          },
           "500": {
             "description": "Internal Server Error response."
           },
@@ -342,36 +465,1467 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "FeedgenMangaRequestBody": {
-      "type": "object",
-      "title": "FeedgenMangaRequestBody",
-      "required": [
-        "titles"
-      ],
-      "properties": {
-        "titles": {
-          "description": "List of manga titles to subscribe to",
-          "type": "array",
-          "maxItems": 2048,
-          "minItems": 1,
-          "items": {
-            "type": "string",
-            "example": "Oyasumi Punpun"
-          },
-          "example": [
-            "Oyasumi Punpun",
-            "Berserk"
-          ]
-        }
-      },
-      "example": {
-        "titles": [
+    },
+    "/api/manga/resolve": {
+      "post": {
+        "description": "Returns what each title matches without creating a feed: exactly one manga, more than one with all of them, or none with the manga it most resembles.\nThe muids of the intended manga can then be given along with the titles when creating a feed.\n",
+        "produces": [
+          "application/json"
+        ],
+        "summary": "Look up manga titles",
+        "operationId": "feedgen#resolveManga",
+        "parameters": [
+          {
+            "name": "ResolveRequestBody",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveRequestBody"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK response.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveResponse"
+            }
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      }
+    },
+    "/api/manga/{muid}/cover": {
+      "get": {
+        "description": "Returns the cached cover image of a manga, or a JPEG thumbnail of it.",
+        "produces": [
+          "image/jpeg",
+          "image/png",
+          "image/gif"
+        ],
+        "summary": "Get manga cover",
+        "operationId": "feedgen#mangaCover",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "MangaUpdates id of the manga",
+            "name": "muid",
+            "in": "path",
+            "required": true
+          },
+          {
+            "enum": [
+              "thumbnail",
+              "original"
+            ],
+            "type": "string",
+            "default": "thumbnail",
+            "description": "Whether to return the thumbnail or the original image",
+            "name": "size",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK response.",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "304": {
+            "description": "Not Modified response."
+          },
+          "404": {
+            "description": "Not Found response."
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "FeedgenImportRequestBody": {
+      "type": "object",
+      "title": "FeedgenImportRequestBody",
+      "required": [
+        "export"
+      ],
+      "properties": {
+        "description": {
+          "description": "Description of an editable feed",
+          "type": "string",
+          "maxLength": 1024
+        },
+        "editable": {
+          "description": "Create a feed whose titles can be changed later with the returned owner token, keeping its URL",
+          "type": "boolean"
+        },
+        "export": {
+          "description": "The exported reading list, either a MyAnimeList XML export, an AniList JSON export or a Kitsu export. Exports can also be sent base64 encoded, which gzipped MyAnimeList exports have to be",
+          "type": "string",
+          "maxLength": 16777216
+        },
+        "format": {
+          "description": "The tracker the reading list was exported from, worked out from the export if missing",
+          "type": "string",
+          "enum": [
+            "mal",
+            "anilist",
+            "kitsu"
+          ]
+        },
+        "name": {
+          "description": "Name of an editable feed, used as the title of the feed",
+          "type": "string",
+          "maxLength": 256
+        },
+        "private": {
+          "description": "Create a feed with a random 128-bit identifier instead of one derived from its titles, so nobody else can find it",
+          "type": "boolean"
+        }
+      }
+    },
+    "FeedgenMangaFeed": {
+      "type": "object",
+      "title": "FeedgenMangaFeed",
+      "properties": {
+        "ambiguous": {
+          "description": "The requested titles that matched more than one manga and were left out of the feed, with allowPartial",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenTitleResolution"
+          }
+        },
+        "description": {
+          "type": "string"
+        },
+        "editable": {
+          "type": "boolean"
+        },
+        "id": {
+          "description": "Identifier of the feed, in place of the hash in its URLs",
+          "type": "string"
+        },
+        "muids": {
+          "description": "MangaUpdates ids of the manga in the feed",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "name": {
+          "type": "string"
+        },
+        "ownerToken": {
+          "description": "Secret needed to change the feed, only returned when it's created",
+          "type": "string"
+        },
+        "private": {
+          "type": "boolean"
+        },
+        "unknownMuids": {
+          "description": "The requested MangaUpdates ids of manga that aren't in the database, couldn't be scraped and were left out of the feed, with allowPartial",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "unmatchedUrls": {
+          "description": "The requested URLs that don't link to a MangaUpdates series and were left out of the feed, with allowPartial",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "unresolved": {
+          "description": "The requested titles that matched no manga and were left out of the feed, with allowPartial",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenUnmatchedTitle"
+          }
+        },
+        "url": {
+          "description": "URL of the feed",
+          "type": "string"
+        }
+      }
+    },
+    "FeedgenMangaPatchBody": {
+      "type": "object",
+      "title": "FeedgenMangaPatchBody",
+      "properties": {
+        "addMuids": {
+          "description": "MangaUpdates ids of manga to add to the feed, which also pick the manga meant by added titles matching more than one",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "addTitles": {
+          "description": "Manga titles to add to the feed",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "string"
+          }
+        },
+        "description": {
+          "description": "New description of the feed, left unchanged if missing",
+          "type": "string",
+          "maxLength": 1024,
+          "x-nullable": true
+        },
+        "name": {
+          "description": "New name of the feed, left unchanged if missing",
+          "type": "string",
+          "maxLength": 256,
+          "x-nullable": true
+        },
+        "removeMuids": {
+          "description": "MangaUpdates ids of manga to remove from the feed",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "removeTitles": {
+          "description": "Manga titles to remove from the feed",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "string"
+          }
+        }
+      },
+      "example": {
+        "addTitles": [
+          "Berserk"
+        ]
+      }
+    },
+    "FeedgenMangaRequestBody": {
+      "type": "object",
+      "title": "FeedgenMangaRequestBody",
+      "properties": {
+        "allowPartial": {
+          "description": "Create the feed from the titles and muids that were found, listing the rest in the response instead of refusing the whole request",
+          "type": "boolean"
+        },
+        "description": {
+          "description": "Description of an editable feed",
+          "type": "string",
+          "maxLength": 1024
+        },
+        "editable": {
+          "description": "Create a feed whose titles can be changed later with the returned owner token, keeping its URL",
+          "type": "boolean"
+        },
+        "muids": {
+          "description": "MangaUpdates ids of manga to subscribe to, which also pick the manga meant by titles matching more than one. Manga missing from the database are scraped",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "name": {
+          "description": "Name of an editable feed, used as the title of the feed",
+          "type": "string",
+          "maxLength": 256
+        },
+        "private": {
+          "description": "Create a feed with a random 128-bit identifier instead of one derived from its titles, so nobody else can find it",
+          "type": "boolean"
+        },
+        "titles": {
+          "description": "List of manga titles to subscribe to",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "string",
+            "example": "Oyasumi Punpun"
+          },
+          "example": [
+            "Oyasumi Punpun",
+            "Berserk"
+          ]
+        },
+        "urls": {
+          "description": "MangaUpdates series URLs of manga to subscribe to, read like muids. Titles that are URLs are read the same way",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "string",
+            "example": "https://www.mangaupdates.com/series.html?id=1"
+          }
+        }
+      },
+      "example": {
+        "titles": [
+          "Oyasumi Punpun"
+        ]
+      }
+    },
+    "FeedgenResolveRequestBody": {
+      "type": "object",
+      "title": "FeedgenResolveRequestBody",
+      "required": [
+        "titles"
+      ],
+      "properties": {
+        "titles": {
+          "description": "Manga titles to look up",
+          "type": "array",
+          "maxItems": 2048,
+          "minItems": 1,
+          "items": {
+            "type": "string"
+          }
+        }
+      },
+      "example": {
+        "titles": [
+          "Berserk"
+        ]
+      }
+    },
+    "FeedgenResolveResponse": {
+      "type": "object",
+      "title": "FeedgenResolveResponse",
+      "properties": {
+        "titles": {
+          "description": "What each requested title matched, in the order requested",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenTitleResolution"
+          }
+        }
+      }
+    },
+    "FeedgenSeries": {
+      "type": "object",
+      "title": "FeedgenSeries",
+      "properties": {
+        "artists": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "authors": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "displayTitle": {
+          "description": "The main title of the manga",
+          "type": "string"
+        },
+        "genres": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "muid": {
+          "description": "MangaUpdates id of the manga",
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "type": "string"
+        },
+        "type": {
+          "type": "string"
+        },
+        "url": {
+          "description": "URL of the manga on MangaUpdates",
+          "type": "string"
+        },
+        "year": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "FeedgenTitleCandidate": {
+      "type": "object",
+      "title": "FeedgenTitleCandidate",
+      "properties": {
+        "displayTitle": {
+          "description": "The main title of the manga",
+          "type": "string"
+        },
+        "muid": {
+          "description": "MangaUpdates id of the manga",
+          "type": "integer",
+          "format": "int64"
+        },
+        "score": {
+          "description": "How alike the titles are, from 0 to 1",
+          "type": "number",
+          "format": "double"
+        },
+        "title": {
+          "description": "The title of the manga that resembles the requested one",
+          "type": "string"
+        }
+      }
+    },
+    "FeedgenTitleResolution": {
+      "type": "object",
+      "title": "FeedgenTitleResolution",
+      "properties": {
+        "candidates": {
+          "description": "The manga with the most alike titles if the title matched none, most alike first",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenTitleCandidate"
+          }
+        },
+        "entry": {
+          "description": "The imported reading list entry the title is from, like myanimelist:2",
+          "type": "string"
+        },
+        "series": {
+          "description": "The manga the title matched",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenSeries"
+          }
+        },
+        "status": {
+          "description": "Whether the title matched exactly one manga, more than one, or none",
+          "type": "string",
+          "enum": [
+            "matched",
+            "ambiguous",
+            "not_found"
+          ]
+        },
+        "title": {
+          "description": "The title as requested",
+          "type": "string"
+        }
+      }
+    },
+    "FeedgenTitlesNotFound": {
+      "type": "object",
+      "title": "FeedgenTitlesNotFound",
+      "properties": {
+        "unknownMuids": {
+          "description": "The requested MangaUpdates ids of manga that aren't in the database and couldn't be scraped",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "unmatched": {
+          "description": "The requested titles that matched no manga",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenUnmatchedTitle"
+          }
+        },
+        "unmatchedUrls": {
+          "description": "The requested URLs that don't link to a MangaUpdates series",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "FeedgenUnmatchedTitle": {
+      "type": "object",
+      "title": "FeedgenUnmatchedTitle",
+      "properties": {
+        "candidates": {
+          "description": "The manga with the most alike titles, most alike first",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenTitleCandidate"
+          }
+        },
+        "entry": {
+          "description": "The imported reading list entry the title is from, like myanimelist:2",
+          "type": "string"
+        },
+        "title": {
+          "description": "The title as requested",
+          "type": "string"
+        }
+      }
+    }
+  }
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json",
+    "application/xml"
+  ],
+  "produces": [
+    "application/json",
+    "application/xml"
+  ],
+  "schemes": [
+    "http"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "description": "Generates update feeds for different types of media",
+    "title": "Feed Generator"
+  },
+  "paths": {
+    "/api/feed/manga": {
+      "post": {
+        "description": "Creates a URL containing the current feed for the requested manga titles, MangaUpdates ids and MangaUpdates series URLs.\nEditable feeds are created with a random identifier and returned along with the secret owner token needed to change them.\nPrivate feeds are also created with a random identifier, so their URL can't be worked out from their titles.\nTitles matching more than one manga are refused unless muids picks which of them is meant, see /api/manga/resolve.\nWith allowPartial, the feed is created from whatever was found as long as something was, and the titles left out are listed alongside it.\n",
+        "summary": "Create feed from manga titles",
+        "operationId": "feedgen#Manga",
+        "parameters": [
+          {
+            "name": "MangaRequestBody",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FeedgenMangaRequestBody"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK response.",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "201": {
+            "description": "Created response, for editable, private or partial feeds.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenMangaFeed"
+            }
+          },
+          "400": {
+            "description": "Bad Request response, if no titles, muids or urls are given."
+          },
+          "404": {
+            "description": "Not Found response, listing the titles that matched no manga with the manga they most resemble.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenTitlesNotFound"
+            }
+          },
+          "409": {
+            "description": "Conflict response, listing the titles that matched more than one manga with all of them.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error response."
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      }
+    },
+    "/api/feed/manga/import": {
+      "post": {
+        "description": "Creates a feed of the manga on a reading list exported from MyAnimeList, AniList or Kitsu.\nEntries are matched by their MangaUpdates cross references, then by every one of their titles, and the ones that couldn't be matched are listed alongside the feed.\n",
+        "summary": "Create feed from an exported reading list",
+        "operationId": "feedgen#importManga",
+        "parameters": [
+          {
+            "name": "ImportRequestBody",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FeedgenImportRequestBody"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Created response.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenMangaFeed"
+            }
+          },
+          "400": {
+            "description": "Bad Request response, if the export can't be read or holds too many manga."
+          },
+          "404": {
+            "description": "Not Found response, if no entry matched a manga, listing them with the manga they most resemble.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenTitlesNotFound"
+            }
+          },
+          "409": {
+            "description": "Conflict response, if every entry that matched a manga matched more than one, listing them with all of them.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error response."
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      }
+    },
+    "/api/feed/manga/{hash}": {
+      "get": {
+        "description": "Returns an RSS/Atom/JSON Feed of the manga titles.",
+        "produces": [
+          "application/xml",
+          "application/json"
+        ],
+        "summary": "Get feed of manga updates",
+        "operationId": "feedgen#viewManga",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Identifier of previously created manga feed",
+            "name": "hash",
+            "in": "path",
+            "required": true
+          },
+          {
+            "enum": [
+              "rss",
+              "atom",
+              "json"
+            ],
+            "type": "string",
+            "default": "atom",
+            "description": "RSS, Atom, or JSON Feed",
+            "name": "feedType",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "integer",
+              "format": "int64"
+            },
+            "collectionFormat": "csv",
+            "description": "Only include releases translated by at least one of these scanlation group ids",
+            "name": "groups",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "integer",
+              "format": "int64"
+            },
+            "collectionFormat": "csv",
+            "description": "Exclude releases translated by any of these scanlation group ids",
+            "name": "excludeGroups",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "Only include releases of manga with at least one of these genres",
+            "name": "genres",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "Exclude releases of manga with any of these genres",
+            "name": "excludeGenres",
+            "in": "query"
+          },
+          {
+            "maximum": 500,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 50,
+            "description": "Most releases in each page of the feed, newest first",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only include releases released at or after this time",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Only include the latest releases of each series, up to this many",
+            "name": "per_series",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Cursor of the release to return the releases older than, linked from the newer page as described by RFC 5005",
+            "name": "before",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Cursor of the release to return the releases newer than, linked from the older page as described by RFC 5005",
+            "name": "after",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK response.",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "Bad Request response, if before or after isn't a cursor from a link of the feed."
+          },
+          "404": {
+            "description": "Not Found response."
+          },
+          "409": {
+            "description": "Conflict response, if the feed's legacy link was given out for two sets of manga. Links to the feed of each set."
+          },
+          "500": {
+            "description": "Internal Server Error response."
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      },
+      "put": {
+        "description": "Replaces the titles, name and description of an editable feed, keeping its URL.",
+        "summary": "Replace the titles of an editable feed",
+        "operationId": "feedgen#updateManga",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Identifier of previously created manga feed",
+            "name": "hash",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Owner token returned when the editable feed was created",
+            "name": "X-Owner-Token",
+            "in": "header",
+            "required": true
+          },
+          {
+            "name": "MangaRequestBody",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FeedgenMangaRequestBody"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK response.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenMangaFeed"
+            }
+          },
+          "400": {
+            "description": "Bad Request response, if no titles, muids or urls are given."
+          },
+          "403": {
+            "description": "Forbidden response, if the feed isn't editable or the owner token is wrong."
+          },
+          "404": {
+            "description": "Not Found response, listing the titles that matched no manga with the manga they most resemble if the feed was found.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenTitlesNotFound"
+            }
+          },
+          "409": {
+            "description": "Conflict response, listing the titles that matched more than one manga with all of them.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error response."
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      },
+      "delete": {
+        "summary": "Delete an editable feed",
+        "operationId": "feedgen#deleteManga",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Identifier of previously created manga feed",
+            "name": "hash",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Owner token returned when the editable feed was created",
+            "name": "X-Owner-Token",
+            "in": "header",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content response."
+          },
+          "403": {
+            "description": "Forbidden response, if the feed isn't editable or the owner token is wrong."
+          },
+          "404": {
+            "description": "Not Found response."
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      },
+      "patch": {
+        "description": "Adds and removes titles of an editable feed and changes its name or description, keeping its URL.",
+        "summary": "Add or remove titles of an editable feed",
+        "operationId": "feedgen#patchManga",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Identifier of previously created manga feed",
+            "name": "hash",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Owner token returned when the editable feed was created",
+            "name": "X-Owner-Token",
+            "in": "header",
+            "required": true
+          },
+          {
+            "name": "MangaPatchBody",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FeedgenMangaPatchBody"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK response.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenMangaFeed"
+            }
+          },
+          "403": {
+            "description": "Forbidden response, if the feed isn't editable or the owner token is wrong."
+          },
+          "404": {
+            "description": "Not Found response, listing the titles that matched no manga with the manga they most resemble if the feed was found.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenTitlesNotFound"
+            }
+          },
+          "409": {
+            "description": "Conflict response, listing the titles that matched more than one manga with all of them.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error response."
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      }
+    },
+    "/api/feed/manga/{hash}/titles/": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "summary": "Get manga titles inside feed",
+        "operationId": "feedgen#viewMangaTitles",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Identifier of previously created manga feed",
+            "name": "hash",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK response.",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "titles": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "Not Found response."
+          },
+          "409": {
+            "description": "Conflict response, if the feed's legacy link was given out for two sets of manga. Links to the feed of each set."
+          },
+          "500": {
+            "description": "Internal Server Error response."
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      }
+    },
+    "/api/manga/resolve": {
+      "post": {
+        "description": "Returns what each title matches without creating a feed: exactly one manga, more than one with all of them, or none with the manga it most resembles.\nThe muids of the intended manga can then be given along with the titles when creating a feed.\n",
+        "produces": [
+          "application/json"
+        ],
+        "summary": "Look up manga titles",
+        "operationId": "feedgen#resolveManga",
+        "parameters": [
+          {
+            "name": "ResolveRequestBody",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveRequestBody"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK response.",
+            "schema": {
+              "$ref": "#/definitions/FeedgenResolveResponse"
+            }
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      }
+    },
+    "/api/manga/{muid}/cover": {
+      "get": {
+        "description": "Returns the cached cover image of a manga, or a JPEG thumbnail of it.",
+        "produces": [
+          "image/jpeg",
+          "image/png",
+          "image/gif"
+        ],
+        "summary": "Get manga cover",
+        "operationId": "feedgen#mangaCover",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "MangaUpdates id of the manga",
+            "name": "muid",
+            "in": "path",
+            "required": true
+          },
+          {
+            "enum": [
+              "thumbnail",
+              "original"
+            ],
+            "type": "string",
+            "default": "thumbnail",
+            "description": "Whether to return the thumbnail or the original image",
+            "name": "size",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK response.",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "304": {
+            "description": "Not Modified response."
+          },
+          "404": {
+            "description": "Not Found response."
+          },
+          "502": {
+            "description": "Bad Gateway response."
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "FeedgenImportRequestBody": {
+      "type": "object",
+      "title": "FeedgenImportRequestBody",
+      "required": [
+        "export"
+      ],
+      "properties": {
+        "description": {
+          "description": "Description of an editable feed",
+          "type": "string",
+          "maxLength": 1024
+        },
+        "editable": {
+          "description": "Create a feed whose titles can be changed later with the returned owner token, keeping its URL",
+          "type": "boolean"
+        },
+        "export": {
+          "description": "The exported reading list, either a MyAnimeList XML export, an AniList JSON export or a Kitsu export. Exports can also be sent base64 encoded, which gzipped MyAnimeList exports have to be",
+          "type": "string",
+          "maxLength": 16777216
+        },
+        "format": {
+          "description": "The tracker the reading list was exported from, worked out from the export if missing",
+          "type": "string",
+          "enum": [
+            "mal",
+            "anilist",
+            "kitsu"
+          ]
+        },
+        "name": {
+          "description": "Name of an editable feed, used as the title of the feed",
+          "type": "string",
+          "maxLength": 256
+        },
+        "private": {
+          "description": "Create a feed with a random 128-bit identifier instead of one derived from its titles, so nobody else can find it",
+          "type": "boolean"
+        }
+      }
+    },
+    "FeedgenMangaFeed": {
+      "type": "object",
+      "title": "FeedgenMangaFeed",
+      "properties": {
+        "ambiguous": {
+          "description": "The requested titles that matched more than one manga and were left out of the feed, with allowPartial",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenTitleResolution"
+          }
+        },
+        "description": {
+          "type": "string"
+        },
+        "editable": {
+          "type": "boolean"
+        },
+        "id": {
+          "description": "Identifier of the feed, in place of the hash in its URLs",
+          "type": "string"
+        },
+        "muids": {
+          "description": "MangaUpdates ids of the manga in the feed",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "name": {
+          "type": "string"
+        },
+        "ownerToken": {
+          "description": "Secret needed to change the feed, only returned when it's created",
+          "type": "string"
+        },
+        "private": {
+          "type": "boolean"
+        },
+        "unknownMuids": {
+          "description": "The requested MangaUpdates ids of manga that aren't in the database, couldn't be scraped and were left out of the feed, with allowPartial",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "unmatchedUrls": {
+          "description": "The requested URLs that don't link to a MangaUpdates series and were left out of the feed, with allowPartial",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "unresolved": {
+          "description": "The requested titles that matched no manga and were left out of the feed, with allowPartial",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenUnmatchedTitle"
+          }
+        },
+        "url": {
+          "description": "URL of the feed",
+          "type": "string"
+        }
+      }
+    },
+    "FeedgenMangaPatchBody": {
+      "type": "object",
+      "title": "FeedgenMangaPatchBody",
+      "properties": {
+        "addMuids": {
+          "description": "MangaUpdates ids of manga to add to the feed, which also pick the manga meant by added titles matching more than one",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "addTitles": {
+          "description": "Manga titles to add to the feed",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "string"
+          }
+        },
+        "description": {
+          "description": "New description of the feed, left unchanged if missing",
+          "type": "string",
+          "maxLength": 1024,
+          "x-nullable": true
+        },
+        "name": {
+          "description": "New name of the feed, left unchanged if missing",
+          "type": "string",
+          "maxLength": 256,
+          "x-nullable": true
+        },
+        "removeMuids": {
+          "description": "MangaUpdates ids of manga to remove from the feed",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "removeTitles": {
+          "description": "Manga titles to remove from the feed",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "string"
+          }
+        }
+      },
+      "example": {
+        "addTitles": [
+          "Berserk"
+        ]
+      }
+    },
+    "FeedgenMangaRequestBody": {
+      "type": "object",
+      "title": "FeedgenMangaRequestBody",
+      "properties": {
+        "allowPartial": {
+          "description": "Create the feed from the titles and muids that were found, listing the rest in the response instead of refusing the whole request",
+          "type": "boolean"
+        },
+        "description": {
+          "description": "Description of an editable feed",
+          "type": "string",
+          "maxLength": 1024
+        },
+        "editable": {
+          "description": "Create a feed whose titles can be changed later with the returned owner token, keeping its URL",
+          "type": "boolean"
+        },
+        "muids": {
+          "description": "MangaUpdates ids of manga to subscribe to, which also pick the manga meant by titles matching more than one. Manga missing from the database are scraped",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "name": {
+          "description": "Name of an editable feed, used as the title of the feed",
+          "type": "string",
+          "maxLength": 256
+        },
+        "private": {
+          "description": "Create a feed with a random 128-bit identifier instead of one derived from its titles, so nobody else can find it",
+          "type": "boolean"
+        },
+        "titles": {
+          "description": "List of manga titles to subscribe to",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "string",
+            "example": "Oyasumi Punpun"
+          },
+          "example": [
+            "Oyasumi Punpun",
+            "Berserk"
+          ]
+        },
+        "urls": {
+          "description": "MangaUpdates series URLs of manga to subscribe to, read like muids. Titles that are URLs are read the same way",
+          "type": "array",
+          "maxItems": 2048,
+          "items": {
+            "type": "string",
+            "example": "https://www.mangaupdates.com/series.html?id=1"
+          }
+        }
+      },
+      "example": {
+        "titles": [
           "Oyasumi Punpun"
         ]
       }
+    },
+    "FeedgenResolveRequestBody": {
+      "type": "object",
+      "title": "FeedgenResolveRequestBody",
+      "required": [
+        "titles"
+      ],
+      "properties": {
+        "titles": {
+          "description": "Manga titles to look up",
+          "type": "array",
+          "maxItems": 2048,
+          "minItems": 1,
+          "items": {
+            "type": "string"
+          }
+        }
+      },
+      "example": {
+        "titles": [
+          "Berserk"
+        ]
+      }
+    },
+    "FeedgenResolveResponse": {
+      "type": "object",
+      "title": "FeedgenResolveResponse",
+      "properties": {
+        "titles": {
+          "description": "What each requested title matched, in the order requested",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenTitleResolution"
+          }
+        }
+      }
+    },
+    "FeedgenSeries": {
+      "type": "object",
+      "title": "FeedgenSeries",
+      "properties": {
+        "artists": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "authors": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "displayTitle": {
+          "description": "The main title of the manga",
+          "type": "string"
+        },
+        "genres": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "muid": {
+          "description": "MangaUpdates id of the manga",
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "type": "string"
+        },
+        "type": {
+          "type": "string"
+        },
+        "url": {
+          "description": "URL of the manga on MangaUpdates",
+          "type": "string"
+        },
+        "year": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "FeedgenTitleCandidate": {
+      "type": "object",
+      "title": "FeedgenTitleCandidate",
+      "properties": {
+        "displayTitle": {
+          "description": "The main title of the manga",
+          "type": "string"
+        },
+        "muid": {
+          "description": "MangaUpdates id of the manga",
+          "type": "integer",
+          "format": "int64"
+        },
+        "score": {
+          "description": "How alike the titles are, from 0 to 1",
+          "type": "number",
+          "format": "double"
+        },
+        "title": {
+          "description": "The title of the manga that resembles the requested one",
+          "type": "string"
+        }
+      }
+    },
+    "FeedgenTitleResolution": {
+      "type": "object",
+      "title": "FeedgenTitleResolution",
+      "properties": {
+        "candidates": {
+          "description": "The manga with the most alike titles if the title matched none, most alike first",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenTitleCandidate"
+          }
+        },
+        "entry": {
+          "description": "The imported reading list entry the title is from, like myanimelist:2",
+          "type": "string"
+        },
+        "series": {
+          "description": "The manga the title matched",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenSeries"
+          }
+        },
+        "status": {
+          "description": "Whether the title matched exactly one manga, more than one, or none",
+          "type": "string",
+          "enum": [
+            "matched",
+            "ambiguous",
+            "not_found"
+          ]
+        },
+        "title": {
+          "description": "The title as requested",
+          "type": "string"
+        }
+      }
+    },
+    "FeedgenTitlesNotFound": {
+      "type": "object",
+      "title": "FeedgenTitlesNotFound",
+      "properties": {
+        "unknownMuids": {
+          "description": "The requested MangaUpdates ids of manga that aren't in the database and couldn't be scraped",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "unmatched": {
+          "description": "The requested titles that matched no manga",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenUnmatchedTitle"
+          }
+        },
+        "unmatchedUrls": {
+          "description": "The requested URLs that don't link to a MangaUpdates series",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "FeedgenUnmatchedTitle": {
+      "type": "object",
+      "title": "FeedgenUnmatchedTitle",
+      "properties": {
+        "candidates": {
+          "description": "The manga with the most alike titles, most alike first",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeedgenTitleCandidate"
+          }
+        },
+        "entry": {
+          "description": "The imported reading list entry the title is from, like myanimelist:2",
+          "type": "string"
+        },
+        "title": {
+          "description": "The title as requested",
+          "type": "string"
+        }
+      }
     }
   }
 }`))
@@ -39,9 +39,27 @@ func NewFeedgenAPI(spec *loads.Document) *FeedgenAPI {
 		XMLConsumer:         runtime.XMLConsumer(),
 		JSONProducer:        runtime.JSONProducer(),
 		XMLProducer:         runtime.XMLProducer(),
+		FeedgenDeleteMangaHandler: FeedgenDeleteMangaHandlerFunc(func(params FeedgenDeleteMangaParams) middleware.Responder {
+			return middleware.NotImplemented("operation FeedgenDeleteManga has not yet been implemented")
+		}),
+		FeedgenImportMangaHandler: FeedgenImportMangaHandlerFunc(func(params FeedgenImportMangaParams) middleware.Responder {
+			return middleware.NotImplemented("operation FeedgenImportManga has not yet been implemented")
+		}),
 		FeedgenMangaHandler: FeedgenMangaHandlerFunc(func(params FeedgenMangaParams) middleware.Responder {
 			return middleware.NotImplemented("operation FeedgenManga has not yet been implemented")
 		}),
+		FeedgenMangaCoverHandler: FeedgenMangaCoverHandlerFunc(func(params FeedgenMangaCoverParams) middleware.Responder {
+			return middleware.NotImplemented("operation FeedgenMangaCover has not yet been implemented")
+		}),
+		FeedgenPatchMangaHandler: FeedgenPatchMangaHandlerFunc(func(params FeedgenPatchMangaParams) middleware.Responder {
+			return middleware.NotImplemented("operation FeedgenPatchManga has not yet been implemented")
+		}),
+		FeedgenResolveMangaHandler: FeedgenResolveMangaHandlerFunc(func(params FeedgenResolveMangaParams) middleware.Responder {
+			return middleware.NotImplemented("operation FeedgenResolveManga has not yet been implemented")
+		}),
+		FeedgenUpdateMangaHandler: FeedgenUpdateMangaHandlerFunc(func(params FeedgenUpdateMangaParams) middleware.Responder {
+			return middleware.NotImplemented("operation FeedgenUpdateManga has not yet been implemented")
+		}),
 		FeedgenViewMangaHandler: FeedgenViewMangaHandlerFunc(func(params FeedgenViewMangaParams) middleware.Responder {
 			return middleware.NotImplemented("operation FeedgenViewManga has not yet been implemented")
 		}),
@@ -83,8 +101,20 @@ type FeedgenAPI struct {
 	// XMLProducer registers a producer for a "application/xml" mime type
 	XMLProducer runtime.Producer
 
+	// FeedgenDeleteMangaHandler sets the operation handler for the feedgen delete manga operation
+	FeedgenDeleteMangaHandler FeedgenDeleteMangaHandler
+	// FeedgenImportMangaHandler sets the operation handler for the feedgen import manga operation
+	FeedgenImportMangaHandler FeedgenImportMangaHandler
 	// FeedgenMangaHandler sets the operation handler for the feedgen manga operation
 	FeedgenMangaHandler FeedgenMangaHandler
+	// FeedgenMangaCoverHandler sets the operation handler for the feedgen manga cover operation
+	FeedgenMangaCoverHandler FeedgenMangaCoverHandler
+	// FeedgenPatchMangaHandler sets the operation handler for the feedgen patch manga operation
+	FeedgenPatchMangaHandler FeedgenPatchMangaHandler
+	// FeedgenResolveMangaHandler sets the operation handler for the feedgen resolve manga operation
+	FeedgenResolveMangaHandler FeedgenResolveMangaHandler
+	// FeedgenUpdateMangaHandler sets the operation handler for the feedgen update manga operation
+	FeedgenUpdateMangaHandler FeedgenUpdateMangaHandler
 	// FeedgenViewMangaHandler sets the operation handler for the feedgen view manga operation
 	FeedgenViewMangaHandler FeedgenViewMangaHandler
 	// FeedgenViewMangaTitlesHandler sets the operation handler for the feedgen view manga titles operation
@@ -160,10 +190,34 @@ func (o *FeedgenAPI) Validate() error {
 		unregistered = append(unregistered, "XMLProducer")
 	}
 
+	if o.FeedgenDeleteMangaHandler == nil {
+		unregistered = append(unregistered, "FeedgenDeleteMangaHandler")
+	}
+
+	if o.FeedgenImportMangaHandler == nil {
+		unregistered = append(unregistered, "FeedgenImportMangaHandler")
+	}
+
 	if o.FeedgenMangaHandler == nil {
 		unregistered = append(unregistered, "FeedgenMangaHandler")
 	}
 
+	if o.FeedgenMangaCoverHandler == nil {
+		unregistered = append(unregistered, "FeedgenMangaCoverHandler")
+	}
+
+	if o.FeedgenPatchMangaHandler == nil {
+		unregistered = append(unregistered, "FeedgenPatchMangaHandler")
+	}
+
+	if o.FeedgenResolveMangaHandler == nil {
+		unregistered = append(unregistered, "FeedgenResolveMangaHandler")
+	}
+
+	if o.FeedgenUpdateMangaHandler == nil {
+		unregistered = append(unregistered, "FeedgenUpdateMangaHandler")
+	}
+
 	if o.FeedgenViewMangaHandler == nil {
 		unregistered = append(unregistered, "FeedgenViewMangaHandler")
 	}
@@ -276,11 +330,41 @@ func (o *FeedgenAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/api/feed/manga/{hash}"] = NewFeedgenDeleteManga(o.context, o.FeedgenDeleteMangaHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/api/feed/manga/import"] = NewFeedgenImportManga(o.context, o.FeedgenImportMangaHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/api/feed/manga"] = NewFeedgenManga(o.context, o.FeedgenMangaHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/manga/{muid}/cover"] = NewFeedgenMangaCover(o.context, o.FeedgenMangaCoverHandler)
+
+	if o.handlers["PATCH"] == nil {
+		o.handlers["PATCH"] = make(map[string]http.Handler)
+	}
+	o.handlers["PATCH"]["/api/feed/manga/{hash}"] = NewFeedgenPatchManga(o.context, o.FeedgenPatchMangaHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/api/manga/resolve"] = NewFeedgenResolveManga(o.context, o.FeedgenResolveMangaHandler)
+
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/api/feed/manga/{hash}"] = NewFeedgenUpdateManga(o.context, o.FeedgenUpdateMangaHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
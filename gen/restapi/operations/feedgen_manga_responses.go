@@ -9,6 +9,8 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+
+	models "github.com/danlock/feedgen/gen/models"
 )
 
 // FeedgenMangaOKCode is the HTTP code returned for type FeedgenMangaOK
@@ -53,14 +55,87 @@ func (o *FeedgenMangaOK) WriteResponse(rw http.ResponseWriter, producer runtime.
 	}
 }
 
+// FeedgenMangaCreatedCode is the HTTP code returned for type FeedgenMangaCreated
+const FeedgenMangaCreatedCode int = 201
+
+/*FeedgenMangaCreated Created response, for editable, private or partial feeds.
+
+swagger:response feedgenMangaCreated
+*/
+type FeedgenMangaCreated struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.FeedgenMangaFeed `json:"body,omitempty"`
+}
+
+// NewFeedgenMangaCreated creates FeedgenMangaCreated with default headers values
+func NewFeedgenMangaCreated() *FeedgenMangaCreated {
+
+	return &FeedgenMangaCreated{}
+}
+
+// WithPayload adds the payload to the feedgen manga created response
+func (o *FeedgenMangaCreated) WithPayload(payload *models.FeedgenMangaFeed) *FeedgenMangaCreated {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the feedgen manga created response
+func (o *FeedgenMangaCreated) SetPayload(payload *models.FeedgenMangaFeed) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *FeedgenMangaCreated) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(201)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// FeedgenMangaBadRequestCode is the HTTP code returned for type FeedgenMangaBadRequest
+const FeedgenMangaBadRequestCode int = 400
+
+/*FeedgenMangaBadRequest Bad Request response, if no titles, muids or urls are given.
+
+swagger:response feedgenMangaBadRequest
+*/
+type FeedgenMangaBadRequest struct {
+}
+
+// NewFeedgenMangaBadRequest creates FeedgenMangaBadRequest with default headers values
+func NewFeedgenMangaBadRequest() *FeedgenMangaBadRequest {
+
+	return &FeedgenMangaBadRequest{}
+}
+
+// WriteResponse to the client
+func (o *FeedgenMangaBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(400)
+}
+
 // FeedgenMangaNotFoundCode is the HTTP code returned for type FeedgenMangaNotFound
 const FeedgenMangaNotFoundCode int = 404
 
-/*FeedgenMangaNotFound Not Found response.
+/*FeedgenMangaNotFound Not Found response, listing the titles that matched no manga with the manga they most resemble.
 
 swagger:response feedgenMangaNotFound
 */
 type FeedgenMangaNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.FeedgenTitlesNotFound `json:"body,omitempty"`
 }
 
 // NewFeedgenMangaNotFound creates FeedgenMangaNotFound with default headers values
@@ -69,12 +144,71 @@ func NewFeedgenMangaNotFound() *FeedgenMangaNotFound {
 	return &FeedgenMangaNotFound{}
 }
 
+// WithPayload adds the payload to the feedgen manga not found response
+func (o *FeedgenMangaNotFound) WithPayload(payload *models.FeedgenTitlesNotFound) *FeedgenMangaNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the feedgen manga not found response
+func (o *FeedgenMangaNotFound) SetPayload(payload *models.FeedgenTitlesNotFound) {
+	o.Payload = payload
+}
+
 // WriteResponse to the client
 func (o *FeedgenMangaNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
-	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
-
 	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// FeedgenMangaConflictCode is the HTTP code returned for type FeedgenMangaConflict
+const FeedgenMangaConflictCode int = 409
+
+/*FeedgenMangaConflict Conflict response, listing the titles that matched more than one manga with all of them.
+
+swagger:response feedgenMangaConflict
+*/
+type FeedgenMangaConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.FeedgenResolveResponse `json:"body,omitempty"`
+}
+
+// NewFeedgenMangaConflict creates FeedgenMangaConflict with default headers values
+func NewFeedgenMangaConflict() *FeedgenMangaConflict {
+
+	return &FeedgenMangaConflict{}
+}
+
+// WithPayload adds the payload to the feedgen manga conflict response
+func (o *FeedgenMangaConflict) WithPayload(payload *models.FeedgenResolveResponse) *FeedgenMangaConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the feedgen manga conflict response
+func (o *FeedgenMangaConflict) SetPayload(payload *models.FeedgenResolveResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *FeedgenMangaConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
 }
 
 // FeedgenMangaInternalServerErrorCode is the HTTP code returned for type FeedgenMangaInternalServerError
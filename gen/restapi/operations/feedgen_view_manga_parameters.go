@@ -6,11 +6,13 @@ package operations
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 
 	strfmt "github.com/go-openapi/strfmt"
@@ -24,10 +26,12 @@ func NewFeedgenViewMangaParams() FeedgenViewMangaParams {
 		// initialize parameters with default values
 
 		feedTypeDefault = string("atom")
+		limitDefault = int64(50)
 	)
 
 	return FeedgenViewMangaParams{
 		FeedType: &feedTypeDefault,
+		Limit: &limitDefault,
 	}
 }
 
@@ -40,16 +44,60 @@ type FeedgenViewMangaParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*Cursor of the release to return the releases newer than, linked from the older page as described by RFC 5005
+	  In: query
+	*/
+	After *string
+	/*Cursor of the release to return the releases older than, linked from the newer page as described by RFC 5005
+	  In: query
+	*/
+	Before *string
+	/*Exclude releases of manga with any of these genres
+	  In: query
+	  Collection Format: csv
+	*/
+	ExcludeGenres []string
+	/*Exclude releases translated by any of these scanlation group ids
+	  In: query
+	  Collection Format: csv
+	*/
+	ExcludeGroups []int64
 	/*RSS, Atom, or JSON Feed
 	  In: query
 	  Default: "atom"
 	*/
 	FeedType *string
+	/*Only include releases of manga with at least one of these genres
+	  In: query
+	  Collection Format: csv
+	*/
+	Genres []string
+	/*Only include releases translated by at least one of these scanlation group ids
+	  In: query
+	  Collection Format: csv
+	*/
+	Groups []int64
 	/*Identifier of previously created manga feed
 	  Required: true
 	  In: path
 	*/
 	Hash string
+	/*Most releases in each page of the feed, newest first
+	  In: query
+	  Maximum: 500
+	  Minimum: 1
+	  Default: 50
+	*/
+	Limit *int64
+	/*Only include the latest releases of each series, up to this many
+	  In: query
+	  Minimum: 1
+	*/
+	PerSeries *int64
+	/*Only include releases released at or after this time
+	  In: query
+	*/
+	Since *strfmt.DateTime
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -63,22 +111,163 @@ func (o *FeedgenViewMangaParams) BindRequest(r *http.Request, route *middleware.
 
 	qs := runtime.Values(r.URL.Query())
 
+	qAfter, qhkAfter, _ := qs.GetOK("after")
+	if err := o.bindAfter(qAfter, qhkAfter, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qBefore, qhkBefore, _ := qs.GetOK("before")
+	if err := o.bindBefore(qBefore, qhkBefore, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qExcludeGenres, qhkExcludeGenres, _ := qs.GetOK("excludeGenres")
+	if err := o.bindExcludeGenres(qExcludeGenres, qhkExcludeGenres, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qExcludeGroups, qhkExcludeGroups, _ := qs.GetOK("excludeGroups")
+	if err := o.bindExcludeGroups(qExcludeGroups, qhkExcludeGroups, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qFeedType, qhkFeedType, _ := qs.GetOK("feedType")
 	if err := o.bindFeedType(qFeedType, qhkFeedType, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	qGenres, qhkGenres, _ := qs.GetOK("genres")
+	if err := o.bindGenres(qGenres, qhkGenres, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qGroups, qhkGroups, _ := qs.GetOK("groups")
+	if err := o.bindGroups(qGroups, qhkGroups, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rHash, rhkHash, _ := route.Params.GetOK("hash")
 	if err := o.bindHash(rHash, rhkHash, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	qLimit, qhkLimit, _ := qs.GetOK("limit")
+	if err := o.bindLimit(qLimit, qhkLimit, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qPerSeries, qhkPerSeries, _ := qs.GetOK("per_series")
+	if err := o.bindPerSeries(qPerSeries, qhkPerSeries, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qSince, qhkSince, _ := qs.GetOK("since")
+	if err := o.bindSince(qSince, qhkSince, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+// bindAfter binds and validates parameter After from query.
+func (o *FeedgenViewMangaParams) bindAfter(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.After = &raw
+
+	return nil
+}
+
+// bindBefore binds and validates parameter Before from query.
+func (o *FeedgenViewMangaParams) bindBefore(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Before = &raw
+
+	return nil
+}
+
+// bindExcludeGenres binds and validates array parameter ExcludeGenres from query.
+//
+// Arrays are parsed according to CollectionFormat: "csv" (defaults to "csv" when empty).
+func (o *FeedgenViewMangaParams) bindExcludeGenres(rawData []string, hasKey bool, formats strfmt.Registry) error {
+
+	var qvExcludeGenres string
+	if len(rawData) > 0 {
+		qvExcludeGenres = rawData[len(rawData)-1]
+	}
+
+	// CollectionFormat: csv
+	excludeGenresIC := swag.SplitByFormat(qvExcludeGenres, "csv")
+	if len(excludeGenresIC) == 0 {
+		return nil
+	}
+
+	var excludeGenresIR []string
+	for _, excludeGenresIV := range excludeGenresIC {
+		excludeGenresI := excludeGenresIV
+
+		excludeGenresIR = append(excludeGenresIR, excludeGenresI)
+	}
+
+	o.ExcludeGenres = excludeGenresIR
+
+	return nil
+}
+
+// bindExcludeGroups binds and validates array parameter ExcludeGroups from query.
+//
+// Arrays are parsed according to CollectionFormat: "csv" (defaults to "csv" when empty).
+func (o *FeedgenViewMangaParams) bindExcludeGroups(rawData []string, hasKey bool, formats strfmt.Registry) error {
+
+	var qvExcludeGroups string
+	if len(rawData) > 0 {
+		qvExcludeGroups = rawData[len(rawData)-1]
+	}
+
+	// CollectionFormat: csv
+	excludeGroupsIC := swag.SplitByFormat(qvExcludeGroups, "csv")
+	if len(excludeGroupsIC) == 0 {
+		return nil
+	}
+
+	var excludeGroupsIR []int64
+	for i, excludeGroupsIV := range excludeGroupsIC {
+		// items.Format: "int64"
+		excludeGroupsI, err := swag.ConvertInt64(excludeGroupsIV)
+		if err != nil {
+			return errors.InvalidType(fmt.Sprintf("%s.%v", "excludeGroups", i), "query", "int64", excludeGroupsI)
+		}
+
+		excludeGroupsIR = append(excludeGroupsIR, excludeGroupsI)
+	}
+
+	o.ExcludeGroups = excludeGroupsIR
+
+	return nil
+}
+
 // bindFeedType binds and validates parameter FeedType from query.
 func (o *FeedgenViewMangaParams) bindFeedType(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -112,6 +301,66 @@ func (o *FeedgenViewMangaParams) validateFeedType(formats strfmt.Registry) error
 	return nil
 }
 
+// bindGenres binds and validates array parameter Genres from query.
+//
+// Arrays are parsed according to CollectionFormat: "csv" (defaults to "csv" when empty).
+func (o *FeedgenViewMangaParams) bindGenres(rawData []string, hasKey bool, formats strfmt.Registry) error {
+
+	var qvGenres string
+	if len(rawData) > 0 {
+		qvGenres = rawData[len(rawData)-1]
+	}
+
+	// CollectionFormat: csv
+	genresIC := swag.SplitByFormat(qvGenres, "csv")
+	if len(genresIC) == 0 {
+		return nil
+	}
+
+	var genresIR []string
+	for _, genresIV := range genresIC {
+		genresI := genresIV
+
+		genresIR = append(genresIR, genresI)
+	}
+
+	o.Genres = genresIR
+
+	return nil
+}
+
+// bindGroups binds and validates array parameter Groups from query.
+//
+// Arrays are parsed according to CollectionFormat: "csv" (defaults to "csv" when empty).
+func (o *FeedgenViewMangaParams) bindGroups(rawData []string, hasKey bool, formats strfmt.Registry) error {
+
+	var qvGroups string
+	if len(rawData) > 0 {
+		qvGroups = rawData[len(rawData)-1]
+	}
+
+	// CollectionFormat: csv
+	groupsIC := swag.SplitByFormat(qvGroups, "csv")
+	if len(groupsIC) == 0 {
+		return nil
+	}
+
+	var groupsIR []int64
+	for i, groupsIV := range groupsIC {
+		// items.Format: "int64"
+		groupsI, err := swag.ConvertInt64(groupsIV)
+		if err != nil {
+			return errors.InvalidType(fmt.Sprintf("%s.%v", "groups", i), "query", "int64", groupsI)
+		}
+
+		groupsIR = append(groupsIR, groupsI)
+	}
+
+	o.Groups = groupsIR
+
+	return nil
+}
+
 // bindHash binds and validates parameter Hash from path.
 func (o *FeedgenViewMangaParams) bindHash(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -126,3 +375,117 @@ func (o *FeedgenViewMangaParams) bindHash(rawData []string, hasKey bool, formats
 
 	return nil
 }
+
+// bindLimit binds and validates parameter Limit from query.
+func (o *FeedgenViewMangaParams) bindLimit(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewFeedgenViewMangaParams()
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("limit", "query", "int64", raw)
+	}
+	o.Limit = &value
+
+	if err := o.validateLimit(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateLimit carries on validations for parameter Limit
+func (o *FeedgenViewMangaParams) validateLimit(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("limit", "query", int64(*o.Limit), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("limit", "query", int64(*o.Limit), 500, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindPerSeries binds and validates parameter PerSeries from query.
+func (o *FeedgenViewMangaParams) bindPerSeries(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("per_series", "query", "int64", raw)
+	}
+	o.PerSeries = &value
+
+	if err := o.validatePerSeries(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validatePerSeries carries on validations for parameter PerSeries
+func (o *FeedgenViewMangaParams) validatePerSeries(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("per_series", "query", int64(*o.PerSeries), 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindSince binds and validates parameter Since from query.
+func (o *FeedgenViewMangaParams) bindSince(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	// Format: date-time
+	value, err := formats.Parse("date-time", raw)
+	if err != nil {
+		return errors.InvalidType("since", "query", "strfmt.DateTime", raw)
+	}
+	o.Since = (value.(*strfmt.DateTime))
+
+	if err := o.validateSince(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSince carries on validations for parameter Since
+func (o *FeedgenViewMangaParams) validateSince(formats strfmt.Registry) error {
+
+	if err := validate.FormatOf("since", "query", "date-time", (*o.Since).String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -10,13 +10,25 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 	"strings"
+
+	"github.com/go-openapi/swag"
+	strfmt "github.com/go-openapi/strfmt"
 )
 
 // FeedgenViewMangaURL generates an URL for the feedgen view manga operation
 type FeedgenViewMangaURL struct {
 	Hash string
 
-	FeedType *string
+	After         *string
+	Before        *string
+	ExcludeGenres []string
+	ExcludeGroups []int64
+	FeedType      *string
+	Genres        []string
+	Groups        []int64
+	Limit         *int64
+	PerSeries     *int64
+	Since         *strfmt.DateTime
 
 	_basePath string
 	// avoid unkeyed usage
@@ -56,6 +68,56 @@ func (o *FeedgenViewMangaURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var after string
+	if o.After != nil {
+		after = *o.After
+	}
+	if after != "" {
+		qs.Set("after", after)
+	}
+
+	var before string
+	if o.Before != nil {
+		before = *o.Before
+	}
+	if before != "" {
+		qs.Set("before", before)
+	}
+
+	var excludeGenresIR []string
+	for _, excludeGenresI := range o.ExcludeGenres {
+		excludeGenresIS := excludeGenresI
+		if excludeGenresIS != "" {
+			excludeGenresIR = append(excludeGenresIR, excludeGenresIS)
+		}
+	}
+
+	excludeGenres := swag.JoinByFormat(excludeGenresIR, "csv")
+
+	if len(excludeGenres) > 0 {
+		qsv := excludeGenres[0]
+		if qsv != "" {
+			qs.Set("excludeGenres", qsv)
+		}
+	}
+
+	var excludeGroupsIR []string
+	for _, excludeGroupsI := range o.ExcludeGroups {
+		excludeGroupsIS := swag.FormatInt64(excludeGroupsI)
+		if excludeGroupsIS != "" {
+			excludeGroupsIR = append(excludeGroupsIR, excludeGroupsIS)
+		}
+	}
+
+	excludeGroups := swag.JoinByFormat(excludeGroupsIR, "csv")
+
+	if len(excludeGroups) > 0 {
+		qsv := excludeGroups[0]
+		if qsv != "" {
+			qs.Set("excludeGroups", qsv)
+		}
+	}
+
 	var feedType string
 	if o.FeedType != nil {
 		feedType = *o.FeedType
@@ -64,6 +126,64 @@ func (o *FeedgenViewMangaURL) Build() (*url.URL, error) {
 		qs.Set("feedType", feedType)
 	}
 
+	var genresIR []string
+	for _, genresI := range o.Genres {
+		genresIS := genresI
+		if genresIS != "" {
+			genresIR = append(genresIR, genresIS)
+		}
+	}
+
+	genres := swag.JoinByFormat(genresIR, "csv")
+
+	if len(genres) > 0 {
+		qsv := genres[0]
+		if qsv != "" {
+			qs.Set("genres", qsv)
+		}
+	}
+
+	var groupsIR []string
+	for _, groupsI := range o.Groups {
+		groupsIS := swag.FormatInt64(groupsI)
+		if groupsIS != "" {
+			groupsIR = append(groupsIR, groupsIS)
+		}
+	}
+
+	groups := swag.JoinByFormat(groupsIR, "csv")
+
+	if len(groups) > 0 {
+		qsv := groups[0]
+		if qsv != "" {
+			qs.Set("groups", qsv)
+		}
+	}
+
+	var limit string
+	if o.Limit != nil {
+		limit = swag.FormatInt64(*o.Limit)
+	}
+	if limit != "" {
+		qs.Set("limit", limit)
+	}
+
+	var per_series string
+	if o.PerSeries != nil {
+		per_series = swag.FormatInt64(*o.PerSeries)
+	}
+	if per_series != "" {
+		qs.Set("per_series", per_series)
+	}
+
+	var since string
+	if o.Since != nil {
+		since = o.Since.String()
+	}
+	if since != "" {
+		qs.Set("since", since)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
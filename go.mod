@@ -30,6 +30,6 @@ require (
 	github.com/pkg/errors v0.8.1
 	github.com/rs/cors v1.6.0
 	golang.org/x/net v0.0.0-20190503192946-f4e77d36d62c
-	golang.org/x/text v0.3.2 // indirect
+	golang.org/x/text v0.3.2
 	google.golang.org/appengine v1.5.0 // indirect
 )
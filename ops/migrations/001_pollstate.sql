@@ -0,0 +1,6 @@
+-- Tracks the last successful poll of each source so the poller can backfill gaps
+CREATE TABLE IF NOT EXISTS public.pollstate (
+	source varchar NOT NULL,
+	last_polled_at timestamp NOT NULL,
+	CONSTRAINT pollstate_pk PRIMARY KEY (source)
+);
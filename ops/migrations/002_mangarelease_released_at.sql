@@ -0,0 +1,4 @@
+-- Separates when a release actually came out from when it was first seen.
+-- Existing releases have no better information, so they keep their first seen date.
+ALTER TABLE public.mangarelease ADD COLUMN IF NOT EXISTS released_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP;
+UPDATE public.mangarelease SET released_at = created_at;
@@ -0,0 +1,9 @@
+-- Structured volume/chapter info parsed from the release text by scrape.ParseRelease.
+-- Releases stored before this migration are left NULL since the parser lives in Go.
+ALTER TABLE public.mangarelease ADD COLUMN IF NOT EXISTS volume int NULL;
+ALTER TABLE public.mangarelease ADD COLUMN IF NOT EXISTS chapter_start int NULL;
+ALTER TABLE public.mangarelease ADD COLUMN IF NOT EXISTS chapter_end int NULL;
+ALTER TABLE public.mangarelease ADD COLUMN IF NOT EXISTS sub_chapter int NULL;
+ALTER TABLE public.mangarelease ADD COLUMN IF NOT EXISTS is_oneshot bool NOT NULL DEFAULT false;
+ALTER TABLE public.mangarelease ADD COLUMN IF NOT EXISTS is_extra bool NOT NULL DEFAULT false;
+ALTER TABLE public.mangarelease ADD COLUMN IF NOT EXISTS is_omake bool NOT NULL DEFAULT false;
@@ -0,0 +1,17 @@
+-- Scanlation groups scraped from MangaUpdates, linked to the releases they translated.
+-- Releases stored before this migration only have the free text translators column.
+CREATE TABLE IF NOT EXISTS public.scanlation_group (
+	group_id int NOT NULL,
+	name varchar NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT scanlation_group_pk PRIMARY KEY (group_id)
+);
+
+CREATE TABLE IF NOT EXISTS public.mangarelease_group (
+	release_id int NOT NULL,
+	group_id int NOT NULL,
+	CONSTRAINT mangarelease_group_pk PRIMARY KEY (release_id,group_id),
+	CONSTRAINT mangarelease_group_release_fk FOREIGN KEY (release_id) REFERENCES public.mangarelease(id) ON DELETE CASCADE,
+	CONSTRAINT mangarelease_group_group_fk FOREIGN KEY (group_id) REFERENCES public.scanlation_group(group_id) ON DELETE CASCADE,
+	INDEX mangarelease_group_group_idx (group_id ASC)
+);
@@ -0,0 +1,28 @@
+-- Series metadata scraped alongside titles. Existing manga get it the next time they are scraped.
+ALTER TABLE public.manga ADD COLUMN IF NOT EXISTS status varchar NOT NULL DEFAULT '';
+ALTER TABLE public.manga ADD COLUMN IF NOT EXISTS "type" varchar NOT NULL DEFAULT '';
+ALTER TABLE public.manga ADD COLUMN IF NOT EXISTS year int NOT NULL DEFAULT 0;
+ALTER TABLE public.manga ADD COLUMN IF NOT EXISTS cover_url varchar NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS public.mangagenre (
+	muid int NOT NULL,
+	genre varchar NOT NULL,
+	CONSTRAINT mangagenre_pk PRIMARY KEY (muid,genre),
+	CONSTRAINT mangagenre_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE,
+	INDEX mangagenre_genre_idx (genre ASC)
+);
+
+CREATE TABLE IF NOT EXISTS public.mangacategory (
+	muid int NOT NULL,
+	category varchar NOT NULL,
+	CONSTRAINT mangacategory_pk PRIMARY KEY (muid,category),
+	CONSTRAINT mangacategory_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.mangaauthor (
+	muid int NOT NULL,
+	role varchar NOT NULL,
+	name varchar NOT NULL,
+	CONSTRAINT mangaauthor_pk PRIMARY KEY (muid,role,name),
+	CONSTRAINT mangaauthor_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE
+);
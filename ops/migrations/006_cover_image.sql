@@ -0,0 +1,19 @@
+-- Cover images cached from the source, stored once per sha256 of the original image along with a JPEG thumbnail.
+CREATE TABLE IF NOT EXISTS public.coverimage (
+	hash varchar NOT NULL,
+	content_type varchar NOT NULL,
+	image bytes NOT NULL,
+	thumbnail bytes NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT coverimage_pk PRIMARY KEY (hash)
+);
+
+CREATE TABLE IF NOT EXISTS public.mangacover (
+	muid int NOT NULL,
+	hash varchar NOT NULL,
+	source_url varchar NOT NULL,
+	fetched_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT mangacover_pk PRIMARY KEY (muid),
+	CONSTRAINT mangacover_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT mangacover_coverimage_fk FOREIGN KEY (hash) REFERENCES public.coverimage(hash)
+);
@@ -0,0 +1,18 @@
+-- Progress of populate-db, so an interrupted run resumes where it stopped and retries the muids that failed.
+CREATE TABLE IF NOT EXISTS public.populatecheckpoint (
+	source varchar NOT NULL,
+	start_muid int NOT NULL,
+	end_muid int NOT NULL,
+	next_muid int NOT NULL,
+	updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT populatecheckpoint_pk PRIMARY KEY (source,start_muid,end_muid)
+);
+
+CREATE TABLE IF NOT EXISTS public.populatefailure (
+	source varchar NOT NULL,
+	muid int NOT NULL,
+	attempts int NOT NULL DEFAULT 1,
+	last_error varchar NOT NULL,
+	failed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT populatefailure_pk PRIMARY KEY (source,muid)
+);
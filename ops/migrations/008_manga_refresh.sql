@@ -0,0 +1,18 @@
+-- Manga are now re-scraped once they go stale. scraped_at is NULL for manga not scraped since this was added, so they are refreshed first.
+ALTER TABLE public.manga ADD COLUMN IF NOT EXISTS scraped_at timestamp NULL;
+CREATE INDEX IF NOT EXISTS manga_scraped_at_idx ON public.manga (scraped_at ASC);
+
+-- Titles no longer listed for a manga are retired instead of deleted.
+ALTER TABLE public.mangatitle ADD COLUMN IF NOT EXISTS retired_at timestamp NULL;
+
+CREATE TABLE IF NOT EXISTS public.mangahistory (
+	id serial NOT NULL,
+	muid int NOT NULL,
+	field varchar NOT NULL,
+	old_value varchar NOT NULL,
+	new_value varchar NOT NULL,
+	changed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT mangahistory_pk PRIMARY KEY (id),
+	CONSTRAINT mangahistory_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE,
+	INDEX mangahistory_muid_idx (muid ASC, changed_at DESC)
+);
@@ -0,0 +1,3 @@
+-- Series deleted from MangaUpdates are retired, and series merged into another also record which one survived.
+ALTER TABLE public.manga ADD COLUMN IF NOT EXISTS retired_at timestamp NULL;
+ALTER TABLE public.manga ADD COLUMN IF NOT EXISTS merged_into int NULL;
@@ -0,0 +1,23 @@
+-- Snapshots of every page scraped, stored once per unique page, so reparse can parse them again after a parser fix.
+CREATE TABLE IF NOT EXISTS public.pagesnapshot (
+	hash varchar NOT NULL,
+	body bytes NOT NULL,
+	size int NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT pagesnapshot_pk PRIMARY KEY (hash)
+);
+
+CREATE TABLE IF NOT EXISTS public.pagefetch (
+	id serial NOT NULL,
+	source varchar NOT NULL,
+	kind varchar NOT NULL,
+	url varchar NOT NULL,
+	muid int NOT NULL DEFAULT 0,
+	day date NULL,
+	hash varchar NOT NULL,
+	fetched_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT pagefetch_pk PRIMARY KEY (id),
+	CONSTRAINT pagefetch_pagesnapshot_fk FOREIGN KEY (hash) REFERENCES public.pagesnapshot(hash),
+	INDEX pagefetch_fetched_at_idx (source ASC, kind ASC, fetched_at ASC),
+	INDEX pagefetch_muid_idx (source ASC, kind ASC, muid ASC, fetched_at DESC)
+);
@@ -0,0 +1,13 @@
+-- Health of every poll for recent releases, used to notice when a parser stops understanding its source.
+CREATE TABLE IF NOT EXISTS public.scrape_runs (
+	id serial NOT NULL,
+	source varchar NOT NULL,
+	started_at timestamp NOT NULL,
+	duration_ms int NOT NULL,
+	releases int NOT NULL,
+	releases_with_muid int NOT NULL,
+	error varchar NOT NULL DEFAULT '',
+	anomalies varchar[] NOT NULL,
+	CONSTRAINT scrape_runs_pk PRIMARY KEY (id),
+	INDEX scrape_runs_source_idx (source ASC, started_at DESC)
+);
@@ -0,0 +1,22 @@
+-- Release titles mapped to their series when the release didn't link to it, and the releases still waiting for an admin to map.
+CREATE TABLE IF NOT EXISTS public.releasetitlemap (
+	source varchar NOT NULL,
+	title varchar NOT NULL,
+	muid int NOT NULL,
+	resolved_by varchar NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT releasetitlemap_pk PRIMARY KEY (source,title)
+);
+
+CREATE TABLE IF NOT EXISTS public.unresolvedrelease (
+	source varchar NOT NULL,
+	title varchar NOT NULL,
+	release varchar NOT NULL,
+	translators varchar NOT NULL,
+	groups jsonb NOT NULL DEFAULT '[]',
+	released_at timestamp NOT NULL,
+	first_seen_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	last_seen_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	seen_count int NOT NULL DEFAULT 1,
+	CONSTRAINT unresolvedrelease_pk PRIMARY KEY (source,title,release,translators)
+);
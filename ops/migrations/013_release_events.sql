@@ -0,0 +1,14 @@
+-- Log of when each release was first stored. Releases from before it was added have no events.
+CREATE TABLE IF NOT EXISTS public.releaseevent (
+	id serial NOT NULL,
+	release_id int NOT NULL,
+	muid int NOT NULL,
+	"release" varchar NOT NULL,
+	translators varchar NOT NULL,
+	event varchar NOT NULL,
+	released_at timestamp NOT NULL,
+	seen_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT releaseevent_pk PRIMARY KEY (id),
+	CONSTRAINT releaseevent_mangarelease_fk FOREIGN KEY (release_id) REFERENCES public.mangarelease(id) ON DELETE CASCADE,
+	INDEX releaseevent_muid_idx (muid ASC, seen_at DESC)
+);
@@ -0,0 +1,6 @@
+-- Editable feeds keep a random hash while their manga change. Only whoever holds the owner token, stored as its sha256, may change them.
+-- Feeds without an owner token are the original feeds hashed from their muids, which never change.
+ALTER TABLE public.mangafeed ADD COLUMN IF NOT EXISTS name varchar NOT NULL DEFAULT '';
+ALTER TABLE public.mangafeed ADD COLUMN IF NOT EXISTS description varchar NOT NULL DEFAULT '';
+ALTER TABLE public.mangafeed ADD COLUMN IF NOT EXISTS owner_token_hash varchar NULL;
+ALTER TABLE public.mangafeed ADD COLUMN IF NOT EXISTS updated_at timestamp NULL;
@@ -0,0 +1,2 @@
+-- Private feeds have a random hash like editable feeds, but are never shared with anyone asking for the same manga.
+ALTER TABLE public.mangafeed ADD COLUMN IF NOT EXISTS private bool NOT NULL DEFAULT false;
@@ -0,0 +1,11 @@
+-- Feeds used to be hashed from their muids concatenated without a separator, so {1,23} and {12,3} got the same hash and whoever asked second got the first feed.
+-- New feeds are hashed from delimited muids behind a version prefix. Old hashes stay in mangafeed, so they keep resolving to the feed they always have.
+-- Run `feedgen feed-collisions` after this to record the old feeds whose hash another set of manga in the db also had.
+CREATE TABLE IF NOT EXISTS public.mangafeedcollision (
+	hash varchar NOT NULL,
+	muids int[] NOT NULL,
+	colliding_muids int[] NOT NULL,
+	detected_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT mangafeedcollision_pk PRIMARY KEY (hash),
+	CONSTRAINT mangafeedcollision_mangafeed_fk FOREIGN KEY (hash) REFERENCES public.mangafeed(hash) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+-- Titles folded by db.FoldTitle match regardless of Unicode representation, case, punctuation or spacing.
+-- Titles stored before this are folded by running `feedgen fold-titles`, until then they only match exactly.
+ALTER TABLE public.mangatitle ADD COLUMN IF NOT EXISTS folded_title varchar NULL;
+CREATE INDEX IF NOT EXISTS mangatitle_folded_title_idx ON public.mangatitle (folded_title ASC);
@@ -0,0 +1,11 @@
+-- Records which source's ids the muids in every table are. MangaUpdates' legacy series.html?id= ids and its API's series_ids
+-- are different id spaces, so the tables only ever hold the ids of one source and feedgen refuses to run with another.
+-- Databases populated before this were scraped from the legacy pages.
+CREATE TABLE IF NOT EXISTS public.muidsource (
+	id int NOT NULL DEFAULT 1,
+	source varchar NOT NULL,
+	claimed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT muidsource_pk PRIMARY KEY (id),
+	CONSTRAINT muidsource_single_row CHECK (id = 1)
+);
+INSERT INTO public.muidsource (id, source) SELECT 1, 'mangaupdates' WHERE EXISTS (SELECT 1 FROM public.manga) ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,11 @@
+-- Chapters are stored as decimals like 10.5, replacing whole chapters with the start chapter's sub chapter alongside,
+-- which sorted c.10.5-11 after c.11 and couldn't tell .5 from .05.
+-- parser_version is the scrape.ReleaseParserVersion that filled in a release's parsed columns. Every release parsed by an
+-- older version, including those stored before migration 003, is parsed again by running `feedgen parse-releases`.
+ALTER TABLE public.mangarelease DROP COLUMN IF EXISTS sub_chapter;
+ALTER TABLE public.mangarelease DROP COLUMN IF EXISTS chapter_start;
+ALTER TABLE public.mangarelease DROP COLUMN IF EXISTS chapter_end;
+ALTER TABLE public.mangarelease ADD COLUMN IF NOT EXISTS chapter_start decimal NULL;
+ALTER TABLE public.mangarelease ADD COLUMN IF NOT EXISTS chapter_end decimal NULL;
+ALTER TABLE public.mangarelease ADD COLUMN IF NOT EXISTS parser_version int NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS mangarelease_parser_version_idx ON public.mangarelease (parser_version ASC);
@@ -0,0 +1,2 @@
+-- Releases are looked up and paged through by when they were released
+CREATE INDEX IF NOT EXISTS mangarelease_released_at_idx ON public.mangarelease (released_at ASC);
@@ -0,0 +1,12 @@
+-- Title candidates are ranked by the trigrams of their folded title they share with the title looked up, in the db.
+-- CockroachDB has no trigram index, so each title's trigrams are kept in mangatitletrigram, indexed by trigram.
+-- Titles stored before this are indexed by running `feedgen fold-titles`, until then they aren't suggested as candidates.
+ALTER TABLE public.mangatitle ADD COLUMN IF NOT EXISTS trigram_count int NULL;
+CREATE TABLE IF NOT EXISTS public.mangatitletrigram (
+	trigram varchar NOT NULL,
+	title varchar NOT NULL,
+	muid int NOT NULL,
+	CONSTRAINT mangatitletrigram_pk PRIMARY KEY (trigram,title,muid),
+	INDEX mangatitletrigram_title_idx (title ASC, muid ASC),
+	CONSTRAINT mangatitletrigram_mangatitle_fk FOREIGN KEY (title,muid) REFERENCES public.mangatitle(title,muid) ON DELETE CASCADE ON UPDATE CASCADE
+);
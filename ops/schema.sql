@@ -3,8 +3,16 @@ CREATE TABLE public.manga (
 	muid int NOT NULL,
 	latest_release varchar NOT NULL,
 	display_title VARCHAR NOT NULL,
+	status varchar NOT NULL DEFAULT '',
+	"type" varchar NOT NULL DEFAULT '',
+	year int NOT NULL DEFAULT 0,
+	cover_url varchar NOT NULL DEFAULT '',
 	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	scraped_at timestamp NULL,
+	retired_at timestamp NULL,
+	merged_into int NULL,
 	UNIQUE INDEX manga_muid_idx (muid ASC),
+	INDEX manga_scraped_at_idx (scraped_at ASC),
 	CONSTRAINT manga_pk PRIMARY KEY (id)
 );
 
@@ -16,23 +24,254 @@ CREATE TABLE public.mangarelease (
 	"release" varchar NOT NULL,
 	translators varchar NOT NULL,
 	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	released_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	volume int NULL,
+	chapter_start decimal NULL,
+	chapter_end decimal NULL,
+	is_oneshot bool NOT NULL DEFAULT false,
+	is_extra bool NOT NULL DEFAULT false,
+	is_omake bool NOT NULL DEFAULT false,
+	parser_version int NOT NULL DEFAULT 0,
 	CONSTRAINT mangarelease_pk PRIMARY KEY (id),
 	CONSTRAINT mangarelease_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE,
-	UNIQUE INDEX mangarelease_un (muid ASC, release ASC, translators ASC)
+	UNIQUE INDEX mangarelease_un (muid ASC, release ASC, translators ASC),
+	INDEX mangarelease_parser_version_idx (parser_version ASC),
+	INDEX mangarelease_released_at_idx (released_at ASC)
 );
 
 ---
 CREATE TABLE public.mangatitle (
 	title varchar NOT NULL,
 	muid int NOT NULL,
+	folded_title varchar NULL,
+	trigram_count int NULL,
+	retired_at timestamp NULL,
 	CONSTRAINT mangatitles_pk PRIMARY KEY (title,muid),
+	INDEX mangatitle_folded_title_idx (folded_title ASC),
 	CONSTRAINT mangatitles_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
+---
+CREATE TABLE public.mangatitletrigram (
+	trigram varchar NOT NULL,
+	title varchar NOT NULL,
+	muid int NOT NULL,
+	CONSTRAINT mangatitletrigram_pk PRIMARY KEY (trigram,title,muid),
+	INDEX mangatitletrigram_title_idx (title ASC, muid ASC),
+	CONSTRAINT mangatitletrigram_mangatitle_fk FOREIGN KEY (title,muid) REFERENCES public.mangatitle(title,muid) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
 ---
 CREATE TABLE public.mangafeed (
 	hash varchar NOT NULL,
 	muids int[] NOT NULL,
+	name varchar NOT NULL DEFAULT '',
+	description varchar NOT NULL DEFAULT '',
+	owner_token_hash varchar NULL,
+	private bool NOT NULL DEFAULT false,
 	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamp NULL,
 	CONSTRAINT mangafeed_pk PRIMARY KEY (hash)
 );
+
+---
+CREATE TABLE public.mangafeedcollision (
+	hash varchar NOT NULL,
+	muids int[] NOT NULL,
+	colliding_muids int[] NOT NULL,
+	detected_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT mangafeedcollision_pk PRIMARY KEY (hash),
+	CONSTRAINT mangafeedcollision_mangafeed_fk FOREIGN KEY (hash) REFERENCES public.mangafeed(hash) ON DELETE CASCADE
+);
+
+---
+CREATE TABLE public.pollstate (
+	source varchar NOT NULL,
+	last_polled_at timestamp NOT NULL,
+	CONSTRAINT pollstate_pk PRIMARY KEY (source)
+);
+
+---
+CREATE TABLE public.scanlation_group (
+	group_id int NOT NULL,
+	name varchar NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT scanlation_group_pk PRIMARY KEY (group_id)
+);
+
+---
+CREATE TABLE public.mangarelease_group (
+	release_id int NOT NULL,
+	group_id int NOT NULL,
+	CONSTRAINT mangarelease_group_pk PRIMARY KEY (release_id,group_id),
+	CONSTRAINT mangarelease_group_release_fk FOREIGN KEY (release_id) REFERENCES public.mangarelease(id) ON DELETE CASCADE,
+	CONSTRAINT mangarelease_group_group_fk FOREIGN KEY (group_id) REFERENCES public.scanlation_group(group_id) ON DELETE CASCADE,
+	INDEX mangarelease_group_group_idx (group_id ASC)
+);
+
+---
+CREATE TABLE public.mangagenre (
+	muid int NOT NULL,
+	genre varchar NOT NULL,
+	CONSTRAINT mangagenre_pk PRIMARY KEY (muid,genre),
+	CONSTRAINT mangagenre_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE,
+	INDEX mangagenre_genre_idx (genre ASC)
+);
+
+---
+CREATE TABLE public.mangacategory (
+	muid int NOT NULL,
+	category varchar NOT NULL,
+	CONSTRAINT mangacategory_pk PRIMARY KEY (muid,category),
+	CONSTRAINT mangacategory_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+---
+CREATE TABLE public.mangaauthor (
+	muid int NOT NULL,
+	role varchar NOT NULL,
+	name varchar NOT NULL,
+	CONSTRAINT mangaauthor_pk PRIMARY KEY (muid,role,name),
+	CONSTRAINT mangaauthor_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+---
+CREATE TABLE public.coverimage (
+	hash varchar NOT NULL,
+	content_type varchar NOT NULL,
+	image bytes NOT NULL,
+	thumbnail bytes NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT coverimage_pk PRIMARY KEY (hash)
+);
+
+---
+CREATE TABLE public.mangacover (
+	muid int NOT NULL,
+	hash varchar NOT NULL,
+	source_url varchar NOT NULL,
+	fetched_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT mangacover_pk PRIMARY KEY (muid),
+	CONSTRAINT mangacover_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT mangacover_coverimage_fk FOREIGN KEY (hash) REFERENCES public.coverimage(hash)
+);
+
+---
+CREATE TABLE public.populatecheckpoint (
+	source varchar NOT NULL,
+	start_muid int NOT NULL,
+	end_muid int NOT NULL,
+	next_muid int NOT NULL,
+	updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT populatecheckpoint_pk PRIMARY KEY (source,start_muid,end_muid)
+);
+
+---
+CREATE TABLE public.populatefailure (
+	source varchar NOT NULL,
+	muid int NOT NULL,
+	attempts int NOT NULL DEFAULT 1,
+	last_error varchar NOT NULL,
+	failed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT populatefailure_pk PRIMARY KEY (source,muid)
+);
+
+---
+CREATE TABLE public.mangahistory (
+	id serial NOT NULL,
+	muid int NOT NULL,
+	field varchar NOT NULL,
+	old_value varchar NOT NULL,
+	new_value varchar NOT NULL,
+	changed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT mangahistory_pk PRIMARY KEY (id),
+	CONSTRAINT mangahistory_manga_fk FOREIGN KEY (muid) REFERENCES public.manga(muid) ON DELETE CASCADE ON UPDATE CASCADE,
+	INDEX mangahistory_muid_idx (muid ASC, changed_at DESC)
+);
+
+---
+CREATE TABLE public.pagesnapshot (
+	hash varchar NOT NULL,
+	body bytes NOT NULL,
+	size int NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT pagesnapshot_pk PRIMARY KEY (hash)
+);
+
+---
+CREATE TABLE public.pagefetch (
+	id serial NOT NULL,
+	source varchar NOT NULL,
+	kind varchar NOT NULL,
+	url varchar NOT NULL,
+	muid int NOT NULL DEFAULT 0,
+	day date NULL,
+	hash varchar NOT NULL,
+	fetched_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT pagefetch_pk PRIMARY KEY (id),
+	CONSTRAINT pagefetch_pagesnapshot_fk FOREIGN KEY (hash) REFERENCES public.pagesnapshot(hash),
+	INDEX pagefetch_fetched_at_idx (source ASC, kind ASC, fetched_at ASC),
+	INDEX pagefetch_muid_idx (source ASC, kind ASC, muid ASC, fetched_at DESC)
+);
+
+---
+CREATE TABLE public.scrape_runs (
+	id serial NOT NULL,
+	source varchar NOT NULL,
+	started_at timestamp NOT NULL,
+	duration_ms int NOT NULL,
+	releases int NOT NULL,
+	releases_with_muid int NOT NULL,
+	error varchar NOT NULL DEFAULT '',
+	anomalies varchar[] NOT NULL,
+	CONSTRAINT scrape_runs_pk PRIMARY KEY (id),
+	INDEX scrape_runs_source_idx (source ASC, started_at DESC)
+);
+
+---
+CREATE TABLE public.releasetitlemap (
+	source varchar NOT NULL,
+	title varchar NOT NULL,
+	muid int NOT NULL,
+	resolved_by varchar NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT releasetitlemap_pk PRIMARY KEY (source,title)
+);
+
+---
+CREATE TABLE public.unresolvedrelease (
+	source varchar NOT NULL,
+	title varchar NOT NULL,
+	release varchar NOT NULL,
+	translators varchar NOT NULL,
+	groups jsonb NOT NULL DEFAULT '[]',
+	released_at timestamp NOT NULL,
+	first_seen_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	last_seen_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	seen_count int NOT NULL DEFAULT 1,
+	CONSTRAINT unresolvedrelease_pk PRIMARY KEY (source,title,release,translators)
+);
+
+---
+CREATE TABLE public.releaseevent (
+	id serial NOT NULL,
+	release_id int NOT NULL,
+	muid int NOT NULL,
+	"release" varchar NOT NULL,
+	translators varchar NOT NULL,
+	event varchar NOT NULL,
+	released_at timestamp NOT NULL,
+	seen_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT releaseevent_pk PRIMARY KEY (id),
+	CONSTRAINT releaseevent_mangarelease_fk FOREIGN KEY (release_id) REFERENCES public.mangarelease(id) ON DELETE CASCADE,
+	INDEX releaseevent_muid_idx (muid ASC, seen_at DESC)
+);
+
+---
+CREATE TABLE public.muidsource (
+	id int NOT NULL DEFAULT 1,
+	source varchar NOT NULL,
+	claimed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT muidsource_pk PRIMARY KEY (id),
+	CONSTRAINT muidsource_single_row CHECK (id = 1)
+);
@@ -0,0 +1,255 @@
+package readinglist
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/danlock/feedgen/lib"
+	"github.com/pkg/errors"
+)
+
+// Format is the tracker a reading list was exported from.
+type Format string
+
+const (
+	// MyAnimeList exports are XML, gzipped when downloaded. Kitsu can export the same XML.
+	MyAnimeList Format = "mal"
+	// AniList exports are the JSON of a MediaListCollection, with or without the GraphQL response around it.
+	AniList Format = "anilist"
+	// Kitsu exports are either MyAnimeList XML or the JSON:API documents of library entries with their manga and mappings included.
+	Kitsu Format = "kitsu"
+)
+
+const ErrUnknownFormat lib.SentinelError = "Unrecognized reading list export"
+
+// Entry is a manga on a reading list.
+type Entry struct {
+	// ID identifies the manga on the tracker, like myanimelist:2, to report entries that couldn't be matched
+	ID string
+	// Titles are every title the tracker knows the manga by, main title first
+	Titles []string
+	// URLs are the MangaUpdates series pages the tracker cross-references the manga to
+	URLs []string
+}
+
+// Parse reads the manga on a reading list exported in format, detecting the format from data if it's empty.
+// Gzipped exports are unzipped first. Anime on the list are left out.
+func Parse(data []byte, format Format) ([]Entry, error) {
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed unzipping export")
+		}
+		if data, err = ioutil.ReadAll(zr); err != nil {
+			return nil, errors.Wrap(err, "Failed unzipping export")
+		}
+	}
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	isXML := bytes.HasPrefix(data, []byte("<"))
+	switch {
+	case isXML && (format == "" || format == MyAnimeList || format == Kitsu):
+		return parseMAL(data)
+	case !isXML && format == AniList:
+		return parseAniList(data)
+	case !isXML && format == Kitsu:
+		return parseKitsu(data)
+	case !isXML && format == "":
+		// Kitsu's JSON:API documents are the only ones with a list of data
+		var probe struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(data, &probe); err != nil {
+			return nil, errors.Wrap(ErrUnknownFormat, err.Error())
+		}
+		if bytes.HasPrefix(bytes.TrimSpace(probe.Data), []byte("[")) {
+			return parseKitsu(data)
+		}
+		return parseAniList(data)
+	}
+	return nil, errors.Wrapf(ErrUnknownFormat, "Export doesn't look like %s", format)
+}
+
+// parseMAL reads a MyAnimeList XML export.
+func parseMAL(data []byte) ([]Entry, error) {
+	var export struct {
+		Manga []struct {
+			ID    int    `xml:"manga_mangadb_id"`
+			Title string `xml:"manga_title"`
+		} `xml:"manga"`
+	}
+	if err := xml.Unmarshal(data, &export); err != nil {
+		return nil, errors.Wrap(ErrUnknownFormat, err.Error())
+	}
+	entries := make([]Entry, 0, len(export.Manga))
+	for _, m := range export.Manga {
+		e := Entry{ID: fmt.Sprintf("myanimelist:%d", m.ID), Titles: uniqueTitles(m.Title)}
+		if len(e.Titles) > 0 {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+type aniListCollection struct {
+	Lists []struct {
+		Entries []struct {
+			Media struct {
+				ID    int    `json:"id"`
+				Type  string `json:"type"`
+				Title struct {
+					UserPreferred string `json:"userPreferred"`
+					Romaji        string `json:"romaji"`
+					English       string `json:"english"`
+					Native        string `json:"native"`
+				} `json:"title"`
+				Synonyms      []string `json:"synonyms"`
+				ExternalLinks []struct {
+					URL string `json:"url"`
+				} `json:"externalLinks"`
+			} `json:"media"`
+		} `json:"entries"`
+	} `json:"lists"`
+}
+
+// parseAniList reads an AniList MediaListCollection, as returned by its GraphQL API or on its own.
+func parseAniList(data []byte) ([]Entry, error) {
+	var export struct {
+		Data struct {
+			MediaListCollection aniListCollection `json:"MediaListCollection"`
+		} `json:"data"`
+		MediaListCollection aniListCollection `json:"MediaListCollection"`
+		aniListCollection
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, errors.Wrap(ErrUnknownFormat, err.Error())
+	}
+	entries := make([]Entry, 0)
+	for _, c := range []aniListCollection{export.Data.MediaListCollection, export.MediaListCollection, export.aniListCollection} {
+		for _, l := range c.Lists {
+			for _, le := range l.Entries {
+				m := le.Media
+				if m.Type != "" && m.Type != "MANGA" {
+					continue
+				}
+				titles := append([]string{m.Title.UserPreferred, m.Title.Romaji, m.Title.English, m.Title.Native}, m.Synonyms...)
+				e := Entry{ID: fmt.Sprintf("anilist:%d", m.ID), Titles: uniqueTitles(titles...)}
+				for _, link := range m.ExternalLinks {
+					if isMUURL(link.URL) {
+						e.URLs = append(e.URLs, link.URL)
+					}
+				}
+				if len(e.Titles) > 0 || len(e.URLs) > 0 {
+					entries = append(entries, e)
+				}
+			}
+		}
+	}
+	return entries, nil
+}
+
+type kitsuRef struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type kitsuResource struct {
+	kitsuRef
+	Attributes struct {
+		CanonicalTitle    string            `json:"canonicalTitle"`
+		Titles            map[string]string `json:"titles"`
+		AbbreviatedTitles []string          `json:"abbreviatedTitles"`
+		ExternalSite      string            `json:"externalSite"`
+		ExternalID        string            `json:"externalId"`
+	} `json:"attributes"`
+	Relationships struct {
+		Manga struct {
+			Data *kitsuRef `json:"data"`
+		} `json:"manga"`
+		Mappings struct {
+			Data []kitsuRef `json:"data"`
+		} `json:"mappings"`
+	} `json:"relationships"`
+}
+
+// muIDPattern matches the ids MangaUpdates has used, decimal for series.html?id= links and base 36 for the newer ones
+var muIDPattern = regexp.MustCompile(`^[0-9a-z]+$`)
+
+// parseKitsu reads Kitsu library entries or manga as JSON:API documents, with the manga and their mappings to other sites included.
+func parseKitsu(data []byte) ([]Entry, error) {
+	var export struct {
+		Data     []kitsuResource `json:"data"`
+		Included []kitsuResource `json:"included"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, errors.Wrap(ErrUnknownFormat, err.Error())
+	}
+	included := make(map[kitsuRef]kitsuResource, len(export.Included))
+	for _, r := range export.Included {
+		included[r.kitsuRef] = r
+	}
+	entries := make([]Entry, 0, len(export.Data))
+	for _, r := range export.Data {
+		manga := r
+		if ref := r.Relationships.Manga.Data; ref != nil {
+			manga = included[*ref]
+		}
+		if manga.Type != "manga" {
+			continue
+		}
+		a := manga.Attributes
+		languages := make([]string, 0, len(a.Titles))
+		for lang := range a.Titles {
+			languages = append(languages, lang)
+		}
+		sort.Strings(languages)
+		titles := []string{a.CanonicalTitle}
+		for _, lang := range languages {
+			titles = append(titles, a.Titles[lang])
+		}
+		e := Entry{ID: "kitsu:" + manga.ID, Titles: uniqueTitles(append(titles, a.AbbreviatedTitles...)...)}
+		for _, ref := range manga.Relationships.Mappings.Data {
+			m := included[ref].Attributes
+			id := strings.ToLower(strings.TrimSpace(m.ExternalID))
+			if m.ExternalSite != "mangaupdates" || !muIDPattern.MatchString(id) {
+				continue
+			}
+			if strings.Trim(id, "0123456789") == "" {
+				e.URLs = append(e.URLs, "https://www.mangaupdates.com/series.html?id="+id)
+			} else {
+				e.URLs = append(e.URLs, "https://www.mangaupdates.com/series/"+id)
+			}
+		}
+		if len(e.Titles) > 0 || len(e.URLs) > 0 {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// uniqueTitles drops the empty titles and the ones differing only in case or surrounding spaces from an earlier one.
+func uniqueTitles(titles ...string) []string {
+	seen := make(map[string]bool, len(titles))
+	unique := make([]string, 0, len(titles))
+	for _, t := range titles {
+		t = strings.TrimSpace(t)
+		key := strings.ToLower(t)
+		if t == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, t)
+	}
+	return unique
+}
+
+// isMUURL tells whether link points at MangaUpdates.
+func isMUURL(link string) bool {
+	return strings.Contains(strings.ToLower(link), "mangaupdates.com/")
+}
@@ -0,0 +1,76 @@
+package readinglist
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed reading fixture %s: %v", name, err)
+	}
+	return data
+}
+
+func TestParse(t *testing.T) {
+	mal := readFixture(t, "mal.xml")
+	zipped := bytes.Buffer{}
+	zw := gzip.NewWriter(&zipped)
+	zw.Write(mal)
+	zw.Close()
+
+	malEntries := []Entry{
+		{ID: "myanimelist:2", Titles: []string{"Berserk"}},
+		{ID: "myanimelist:13", Titles: []string{"One Piece"}},
+	}
+	aniListEntries := []Entry{
+		{ID: "anilist:30013", Titles: []string{"ONE PIECE", "Wan Pīsu"}, URLs: []string{"https://www.mangaupdates.com/series/pb8uwds/one-piece"}},
+		{ID: "anilist:30002", Titles: []string{"Berserk", "ベルセルク"}},
+	}
+	bareAniListEntries := []Entry{
+		{ID: "anilist:30002", Titles: []string{"Berserk", "ベルセルク", "Kenpuu Denki Berserk"}, URLs: []string{"https://www.mangaupdates.com/series.html?id=88"}},
+	}
+	kitsuEntries := []Entry{
+		{ID: "kitsu:38", Titles: []string{"One Piece", "OP"}, URLs: []string{"https://www.mangaupdates.com/series/pb8uwds"}},
+		{ID: "kitsu:40", Titles: []string{"Berserk", "ベルセルク"}, URLs: []string{"https://www.mangaupdates.com/series.html?id=88"}},
+	}
+	tests := []struct {
+		name   string
+		data   []byte
+		format Format
+		want   []Entry
+		err    error
+	}{
+		{"mal", mal, MyAnimeList, malEntries, nil},
+		{"mal detected", mal, "", malEntries, nil},
+		{"mal gzipped", zipped.Bytes(), "", malEntries, nil},
+		{"mal from kitsu", mal, Kitsu, malEntries, nil},
+		{"mal with bom", append([]byte("\xef\xbb\xbf"), mal...), "", malEntries, nil},
+		{"anilist", readFixture(t, "anilist.json"), AniList, aniListEntries, nil},
+		{"anilist detected", readFixture(t, "anilist.json"), "", aniListEntries, nil},
+		{"anilist bare", readFixture(t, "anilist_bare.json"), AniList, bareAniListEntries, nil},
+		{"anilist bare detected", readFixture(t, "anilist_bare.json"), "", bareAniListEntries, nil},
+		{"kitsu", readFixture(t, "kitsu.json"), Kitsu, kitsuEntries, nil},
+		{"kitsu detected", readFixture(t, "kitsu.json"), "", kitsuEntries, nil},
+		{"xml as anilist", mal, AniList, nil, ErrUnknownFormat},
+		{"json as mal", readFixture(t, "kitsu.json"), MyAnimeList, nil, ErrUnknownFormat},
+		{"not an export", []byte("Berserk, One Piece"), "", nil, ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.data, tt.format)
+		if errors.Cause(err) != tt.err {
+			t.Errorf("%s: Parse returned err %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Parse returned %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package scrape
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/danlock/feedgen/lib"
+	"github.com/pkg/errors"
+)
+
+// maxCoverBytes limits how much of a cover image is downloaded, since covers are expected to be well under a megabyte
+const maxCoverBytes = 10 << 20
+
+const ErrCoverTooLarge lib.SentinelError = "Cover image too large"
+
+// DownloadCover fetches the cover image at coverURL, returning its bytes and the Content-Type the server reported.
+func DownloadCover(ctx context.Context, coverURL string) ([]byte, string, error) {
+	resp, err := DefaultFetcher().Get(ctx, coverURL)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", errors.Errorf("Failed downloading cover %s, got status %s", coverURL, resp.Status)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxCoverBytes+1))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Failed reading cover")
+	}
+	if len(data) > maxCoverBytes {
+		return nil, "", ErrCoverTooLarge
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
@@ -0,0 +1,404 @@
+package scrape
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/danlock/feedgen/lib"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultUserAgent = "feedgen (+https://github.com/danlock/feedgen)"
+	// CacheHeader is set on responses Fetcher.GetCached answered from its cache because the server said they were unchanged
+	CacheHeader = "X-Feedgen-Cache"
+	// maxCachedBody is the largest response body GetCached will keep around
+	maxCachedBody = 2 << 20
+)
+
+const ErrDisallowedByRobots lib.SentinelError = "Disallowed by robots.txt"
+
+// FetcherOptions configures a Fetcher. Zero values are replaced by the defaults noted on each field.
+type FetcherOptions struct {
+	// UserAgent is sent with every request and matched against robots.txt. Defaults to DefaultUserAgent.
+	UserAgent string
+	// RequestsPerSecond is how many requests each host gets on average. Defaults to 2.
+	RequestsPerSecond float64
+	// Burst is how many requests a host can get at once after being left alone for a while. Defaults to 4.
+	Burst int
+	// MaxRetries is how many times a request is retried after a network error, 429 or 5xx. Defaults to 5, set it negative to never retry.
+	MaxRetries int
+	// MinBackoff is the longest wait before the first retry, doubling with every retry after. Defaults to 1 second.
+	MinBackoff time.Duration
+	// MaxBackoff caps the wait between retries, unless a Retry-After header asks for longer. Defaults to 1 minute.
+	MaxBackoff time.Duration
+	// Timeout limits how long a single attempt at a request can take. Defaults to 30 seconds.
+	Timeout time.Duration
+	// CacheSize is how many responses GetCached remembers for conditional requests. Defaults to 64.
+	CacheSize int
+	// Transport sends the requests. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+func (o FetcherOptions) withDefaults() FetcherOptions {
+	if o.UserAgent == "" {
+		o.UserAgent = DefaultUserAgent
+	}
+	if o.RequestsPerSecond <= 0 {
+		o.RequestsPerSecond = 2
+	}
+	if o.Burst < 1 {
+		o.Burst = 4
+	}
+	if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	} else if o.MaxRetries == 0 {
+		o.MaxRetries = 5
+	}
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = time.Minute
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 30 * time.Second
+	}
+	if o.CacheSize < 1 {
+		o.CacheSize = 64
+	}
+	return o
+}
+
+// Fetcher is the HTTP client every scraper goes through, so every host is treated politely no matter which Source is calling it.
+// It rate limits each host, retries failures with backoff, obeys robots.txt and can revalidate pages it has already downloaded.
+type Fetcher struct {
+	opts   FetcherOptions
+	client *http.Client
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	robots  map[string]*robotsRules
+
+	cacheMu    sync.Mutex
+	cache      map[string]*list.Element
+	cacheOrder *list.List
+}
+
+// cachedResponse is a response kept for conditional requests.
+type cachedResponse struct {
+	url          string
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+// NewFetcher creates a Fetcher with opts.
+func NewFetcher(opts FetcherOptions) *Fetcher {
+	opts = opts.withDefaults()
+	transport := opts.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Fetcher{
+		opts:       opts,
+		client:     &http.Client{Transport: transport, Timeout: opts.Timeout},
+		buckets:    make(map[string]*tokenBucket),
+		robots:     make(map[string]*robotsRules),
+		cache:      make(map[string]*list.Element),
+		cacheOrder: list.New(),
+	}
+}
+
+var (
+	defaultFetcherMu sync.RWMutex
+	defaultFetcher   = NewFetcher(FetcherOptions{})
+)
+
+// DefaultFetcher returns the Fetcher used by the scrapers in this package unless they are given one.
+func DefaultFetcher() *Fetcher {
+	defaultFetcherMu.RLock()
+	defer defaultFetcherMu.RUnlock()
+	return defaultFetcher
+}
+
+// SetDefaultFetcher replaces the Fetcher returned by DefaultFetcher.
+func SetDefaultFetcher(f *Fetcher) {
+	defaultFetcherMu.Lock()
+	defer defaultFetcherMu.Unlock()
+	defaultFetcher = f
+}
+
+// Get fetches rawURL with Do.
+func (f *Fetcher) Get(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed creating request")
+	}
+	return f.Do(req.WithContext(ctx))
+}
+
+// GetCached fetches rawURL with Do, sending the ETag and Last-Modified of the last response for it.
+// If the server says the page hasn't changed, the last response is returned again with CacheHeader set.
+func (f *Fetcher) GetCached(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed creating request")
+	}
+	req = req.WithContext(ctx)
+	cached := f.cached(rawURL)
+	if cached != nil {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+	resp, err := f.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		logger.Dbgf(ctx, "%s is unchanged, reusing the cached response", rawURL)
+		return cached.response(req), nil
+	}
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") || resp.ContentLength > maxCachedBody {
+		return resp, nil
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxCachedBody+1))
+	resp.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed reading response")
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if len(body) <= maxCachedBody {
+		f.store(&cachedResponse{url: rawURL, etag: etag, lastModified: lastModified, header: resp.Header, body: body})
+	}
+	return resp, nil
+}
+
+// Do sends req once the host's rate limit allows, retrying network errors, 429s and 5xxs with capped exponential backoff.
+// A Retry-After header on a 429 or 503 pauses every request to the host for as long as it asks.
+// Requests robots.txt disallows fail with ErrDisallowedByRobots. The returned response may have any status code.
+func (f *Fetcher) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	req.Header.Set("User-Agent", f.opts.UserAgent)
+	if req.URL.Path != robotsPath {
+		allowed, err := f.allowedByRobots(ctx, req.URL)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, ErrDisallowedByRobots
+		}
+	}
+	bucket := f.bucket(req.URL.Host)
+	for attempt := 0; ; attempt++ {
+		if err := bucket.wait(ctx); err != nil {
+			return nil, err
+		}
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, errors.Wrap(err, "Failed rewinding request body")
+			}
+			req.Body = body
+		}
+		resp, err := f.client.Do(req)
+		retryAfter := time.Duration(0)
+		if err == nil {
+			if !shouldRetry(resp.StatusCode) {
+				return resp, nil
+			}
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			if retryAfter > 0 && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+				bucket.pause(retryAfter)
+			}
+		}
+		if attempt >= f.opts.MaxRetries {
+			if err != nil {
+				return nil, errors.Wrap(err, "Failed sending request")
+			}
+			return resp, nil
+		}
+		backoff := f.backoff(attempt)
+		if retryAfter > backoff {
+			backoff = retryAfter
+		}
+		if err != nil {
+			logger.Warnf(ctx, "Failed requesting %s, retrying in %s err: %+v", req.URL, backoff, err)
+		} else {
+			logger.Warnf(ctx, "Requesting %s returned %s, retrying in %s", req.URL, resp.Status, backoff)
+			resp.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// shouldRetry reports whether a response with code is worth trying again.
+func shouldRetry(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// backoff returns a random wait up to MinBackoff doubled for each attempt so far, capped at MaxBackoff.
+func (f *Fetcher) backoff(attempt int) time.Duration {
+	max := float64(f.opts.MinBackoff) * math.Pow(2, float64(attempt))
+	if max > float64(f.opts.MaxBackoff) {
+		max = float64(f.opts.MaxBackoff)
+	}
+	return time.Duration(rand.Int63n(int64(max) + 1))
+}
+
+// parseRetryAfter parses a Retry-After header in either seconds or HTTP date form, returning 0 if it's missing or invalid.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+func (f *Fetcher) bucket(host string) *tokenBucket {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.buckets[host]
+	if !ok {
+		b = newTokenBucket(f.opts.RequestsPerSecond, f.opts.Burst)
+		f.buckets[host] = b
+	}
+	return b
+}
+
+func (f *Fetcher) cached(rawURL string) *cachedResponse {
+	f.cacheMu.Lock()
+	defer f.cacheMu.Unlock()
+	el, ok := f.cache[rawURL]
+	if !ok {
+		return nil
+	}
+	f.cacheOrder.MoveToFront(el)
+	return el.Value.(*cachedResponse)
+}
+
+// store caches cr, evicting the least recently used response if the cache is full.
+func (f *Fetcher) store(cr *cachedResponse) {
+	f.cacheMu.Lock()
+	defer f.cacheMu.Unlock()
+	if el, ok := f.cache[cr.url]; ok {
+		el.Value = cr
+		f.cacheOrder.MoveToFront(el)
+		return
+	}
+	f.cache[cr.url] = f.cacheOrder.PushFront(cr)
+	for f.cacheOrder.Len() > f.opts.CacheSize {
+		oldest := f.cacheOrder.Back()
+		f.cacheOrder.Remove(oldest)
+		delete(f.cache, oldest.Value.(*cachedResponse).url)
+	}
+}
+
+// response recreates the cached response as the answer to req.
+func (cr *cachedResponse) response(req *http.Request) *http.Response {
+	header := make(http.Header, len(cr.header)+1)
+	for k, v := range cr.header {
+		header[k] = v
+	}
+	header.Set(CacheHeader, "HIT")
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(cr.body)),
+		ContentLength: int64(len(cr.body)),
+		Request:       req,
+	}
+}
+
+// tokenBucket lets through rate requests per second on average, and up to burst at once.
+type tokenBucket struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait blocks until a token is available and takes it.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		var delay time.Duration
+		if now.Before(b.pausedUntil) {
+			delay = b.pausedUntil.Sub(now)
+		} else {
+			b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+			b.last = now
+			if b.tokens >= 1 {
+				b.tokens--
+				b.mu.Unlock()
+				return nil
+			}
+			delay = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		}
+		b.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// pause stops letting requests through for d, and spends the tokens saved up so requests trickle back in afterwards.
+func (b *tokenBucket) pause(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until := time.Now().Add(d); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+		b.tokens = 0
+		b.last = until
+	}
+}
+
+// setRate slows the bucket down to at most one request every interval, as asked by a robots.txt Crawl-delay.
+func (b *tokenBucket) setRate(interval time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if rate := float64(time.Second) / float64(interval); rate < b.rate {
+		b.rate = rate
+		b.burst = 1
+		b.tokens = math.Min(b.tokens, b.burst)
+	}
+}
@@ -0,0 +1,62 @@
+package scrape
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ScrapeRun is the outcome of polling a Source for its recent releases once.
+type ScrapeRun struct {
+	Source    string        `json:"source"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	// Releases is how many releases were scraped, WithMUID how many of those link to their series
+	Releases int `json:"releases"`
+	WithMUID int `json:"releases_with_muid"`
+	// Error is set if the scrape failed
+	Error string `json:"error,omitempty"`
+	// Anomalies are the reasons the run looks like the parser no longer understands the Source, empty if it looks healthy
+	Anomalies []string `json:"anomalies,omitempty"`
+}
+
+// Anomalous reports whether anything about the run looked wrong.
+func (r ScrapeRun) Anomalous() bool { return len(r.Anomalies) > 0 }
+
+const (
+	// minHealthyHistory is how many healthy runs are needed before a run's release count is compared against them
+	minHealthyHistory = 5
+	// releaseCountTolerance is how many times fewer or more releases than the median healthy run a run may scrape
+	releaseCountTolerance = 4
+	// minMUIDShare is the smallest share of releases expected to link to their series
+	minMUIDShare = 0.5
+)
+
+// CheckScrapeRun returns the anomalies of run compared to history, the previous runs of its Source.
+// Releases pages always list something, so a run that succeeds with no releases is as suspicious as one that fails.
+func CheckScrapeRun(run ScrapeRun, history []ScrapeRun) []string {
+	if run.Error != "" {
+		return []string{"scrape failed: " + run.Error}
+	}
+	if run.Releases == 0 {
+		return []string{"no releases scraped"}
+	}
+	anomalies := make([]string, 0)
+	if share := float64(run.WithMUID) / float64(run.Releases); share < minMUIDShare {
+		anomalies = append(anomalies, fmt.Sprintf("only %.0f%% of releases have an MUID", share*100))
+	}
+	counts := make([]int, 0, len(history))
+	for _, h := range history {
+		if !h.Anomalous() {
+			counts = append(counts, h.Releases)
+		}
+	}
+	if len(counts) >= minHealthyHistory {
+		sort.Ints(counts)
+		median := counts[len(counts)/2]
+		if run.Releases*releaseCountTolerance < median || run.Releases > median*releaseCountTolerance {
+			anomalies = append(anomalies, fmt.Sprintf("scraped %d releases while recent runs scraped around %d", run.Releases, median))
+		}
+	}
+	return anomalies
+}
@@ -1,35 +1,52 @@
 package scrape
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"math/rand"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/antchfx/htmlquery"
 	"github.com/danlock/feedgen/lib"
-	"github.com/danlock/feedgen/lib/logger"
 	"github.com/pkg/errors"
 	"golang.org/x/net/html"
 )
 
 const muReleasesURL = "https://www.mangaupdates.com/releases.html"
+const muReleasesArchiveURLFormat = "https://www.mangaupdates.com/releases.html?act=archive&date=%s&page=%d&perpage=100"
 const muInfoURLFormat = "https://www.mangaupdates.com/series.html?id=%d"
+const muSearchURLFormat = "https://www.mangaupdates.com/series.html?search=%s&perpage=50"
 
 func GetMUPageURL(muid int) string {
 	return fmt.Sprintf(muInfoURLFormat, muid)
 }
 
+// getMUSeriesURL returns the newer URL form of a series page, which encodes the API's series_id in base 36.
+func getMUSeriesURL(seriesID int) string {
+	return "https://www.mangaupdates.com/series/" + strconv.FormatInt(int64(seriesID), 36)
+}
+
+// ScanlationGroup is a group that translates releases, identified by its MangaUpdates group id.
+type ScanlationGroup struct {
+	ID   int
+	Name string
+}
+
 type MangaRelease struct {
 	MUID        int
 	Title       string
 	Release     string
 	Translators string
-	CreatedAt   time.Time
+	Groups      []ScanlationGroup
+	// CreatedAt is when the release was scraped, ReleasedAt is the day the source listed it under.
+	CreatedAt  time.Time
+	ReleasedAt time.Time
 }
 
 type MangaInfo struct {
@@ -37,12 +54,58 @@ type MangaInfo struct {
 	Titles        []string
 	DisplayTitle  string
 	LatestRelease string
+	// Metadata about the series, left empty when the source doesn't list it
+	Genres     []string
+	Categories []string
+	Authors    []string
+	Artists    []string
+	Status     string
+	Type       string
+	Year       int
+	CoverURL   string
+	// ScrapedAt is when the series page was fetched, left zero for it to be saved as scraped now
+	ScrapedAt time.Time
+}
+
+// muReleaseDayFormats are the formats MangaUpdates uses for the heading above each day of releases, once ordinal suffixes are removed.
+var muReleaseDayFormats = []string{"Monday, January 2 2006", "January 2 2006", "Monday, January 2, 2006", "January 2, 2006", "01/02/06", "2006-01-02"}
+
+var ordinalSuffixRegexp = regexp.MustCompile(`(\d)(st|nd|rd|th)\b`)
+
+// parseMUReleaseDay parses the day out of a release table heading such as "Saturday, May 11th 2019".
+func parseMUReleaseDay(heading string) (time.Time, error) {
+	heading = ordinalSuffixRegexp.ReplaceAllString(strings.Join(strings.Fields(heading), " "), "$1")
+	for _, format := range muReleaseDayFormats {
+		if day, err := time.Parse(format, heading); err == nil {
+			return day, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("Unrecognized release day heading %s", heading)
 }
 
-func parseMUDailyReleases(table *html.Node) ([]MangaRelease, error) {
+// findMUReleaseDay looks through the nodes preceding table for the heading of the day it lists releases for.
+func findMUReleaseDay(table *html.Node) (time.Time, bool) {
+	// The heading is a sibling of one of the table's ancestors, so only search a few levels up to avoid finding another day's heading
+	const maxDepth = 3
+	node := table
+	for depth := 0; node != nil && depth < maxDepth; depth++ {
+		for sibling := node.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+			if day, err := parseMUReleaseDay(htmlquery.InnerText(sibling)); err == nil {
+				return day, true
+			}
+		}
+		node = node.Parent
+	}
+	return time.Time{}, false
+}
+
+// ErrUnexpectedLayout means a page doesn't look the way its parser expects, usually because the source changed its markup.
+const ErrUnexpectedLayout lib.SentinelError = "Unexpected page layout"
+
+// parseMUDailyReleases parses a table of releases that were released on the given day and scraped at now.
+func parseMUDailyReleases(table *html.Node, releasedAt, now time.Time) ([]MangaRelease, error) {
 	allMangaReleases := make([]MangaRelease, 0)
-	now := time.Now()
-	currentMangaRelease := MangaRelease{CreatedAt: now}
+	currentMangaRelease := MangaRelease{CreatedAt: now, ReleasedAt: releasedAt}
 
 	release := table
 	for release.NextSibling != nil {
@@ -55,7 +118,7 @@ func parseMUDailyReleases(table *html.Node) ([]MangaRelease, error) {
 					if len(currentMangaRelease.Title) > 0 {
 						allMangaReleases = append(allMangaReleases, currentMangaRelease)
 					}
-					currentMangaRelease = MangaRelease{CreatedAt: now}
+					currentMangaRelease = MangaRelease{CreatedAt: now, ReleasedAt: releasedAt}
 					if releaseLinks == nil {
 						currentMangaRelease.Title = strings.ToLower(htmlquery.InnerText(release))
 					} else {
@@ -82,6 +145,14 @@ func parseMUDailyReleases(table *html.Node) ([]MangaRelease, error) {
 					} else {
 						currentMangaRelease.Translators = htmlquery.InnerText(releaseLinks)
 					}
+					// Each group translating the release has its own link
+					for _, groupLink := range htmlquery.Find(release, "//a") {
+						groupID := parseMULinkID(htmlquery.SelectAttr(groupLink, "href"))
+						name := strings.TrimSpace(htmlquery.InnerText(groupLink))
+						if groupID > 0 && name != "" {
+							currentMangaRelease.Groups = append(currentMangaRelease.Groups, ScanlationGroup{ID: groupID, Name: name})
+						}
+					}
 				case strings.Contains(attr.Val, "col-2"):
 					currentMangaRelease.Release = strings.TrimSpace(htmlquery.InnerText(release))
 				}
@@ -89,11 +160,89 @@ func parseMUDailyReleases(table *html.Node) ([]MangaRelease, error) {
 		}
 	}
 	// The first index has the header row of the table (Title, Releases, etc)
+	if len(allMangaReleases) == 0 {
+		return allMangaReleases, nil
+	}
+	// Without it the rows are likely laid out differently too, so don't trust any of them
+	if header := allMangaReleases[0]; header.MUID != 0 || strings.TrimSpace(header.Title) != "title" {
+		return nil, errors.Wrapf(ErrUnexpectedLayout, "Release table starts with %q instead of its header row", header.Title)
+	}
 	return allMangaReleases[1:], nil
 }
 
+// parseMULinkID returns the id query parameter of a MangaUpdates link like groups.html?id=123, or 0 if it has none.
+func parseMULinkID(href string) int {
+	link, err := url.Parse(href)
+	if err != nil {
+		return 0
+	}
+	id, err := strconv.Atoi(link.Query().Get("id"))
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// isMUHost tells whether host belongs to MangaUpdates, with or without a subdomain like www.
+func isMUHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "mangaupdates.com" || strings.HasSuffix(host, ".mangaupdates.com")
+}
+
+// parseLegacyMUSeriesURL returns the id of a series.html?id= link, or 0 if u isn't one.
+func parseLegacyMUSeriesURL(u *url.URL) int {
+	if !isMUHost(u.Hostname()) || strings.ToLower(strings.TrimSuffix(u.Path, "/")) != "/series.html" {
+		return 0
+	}
+	return parseMULinkID(u.String())
+}
+
+// parseMUSeriesURL returns the series_id of the newer URL forms, like www.mangaupdates.com/series/<base 36 id>/<slug>
+// and api.mangaupdates.com/v1/series/<id>, or 0 if u isn't one.
+func parseMUSeriesURL(u *url.URL) int {
+	if !isMUHost(u.Hostname()) {
+		return 0
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	base := 36
+	if len(parts) >= 3 && parts[0] == "v1" && parts[1] == "series" {
+		parts, base = parts[1:], 10
+	}
+	if len(parts) < 2 || parts[0] != "series" {
+		return 0
+	}
+	id, err := strconv.ParseInt(parts[1], base, 64)
+	if err != nil || id < 1 {
+		return 0
+	}
+	return int(id)
+}
+
+// getMUSeriesPageLinks fetches a series page, returning the URL it redirected to and its canonical links.
+// MangaUpdates redirects legacy links to the newer URL form, and the page under either form links to the other.
+func getMUSeriesPageLinks(ctx context.Context, fetcher *Fetcher, pageURL string) ([]*url.URL, error) {
+	resp, err := fetcher.Get(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrNotSeriesURL
+	}
+	root, err := htmlquery.Parse(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed parsing series page")
+	}
+	links := []*url.URL{resp.Request.URL}
+	for _, n := range htmlquery.Find(root, "//link[@rel='canonical']/@href | //meta[@property='og:url']/@content") {
+		if link, err := resp.Request.URL.Parse(strings.TrimSpace(htmlquery.InnerText(n))); err == nil {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
 const ErrInvalidMUID lib.SentinelError = "Invalid MUID"
-const maxFailedRequests = 10
 
 func GetAndParseMUMangaPage(ctx context.Context, id int) (m MangaInfo, err error) {
 	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf(muInfoURLFormat, id), nil)
@@ -103,36 +252,36 @@ func GetAndParseMUMangaPage(ctx context.Context, id int) (m MangaInfo, err error
 	req = req.WithContext(ctx)
 	// MangaUpdates doesn't seem to reliably advertise Keep-Alive connection status and Go doesnt handle that very well, so close every request
 	req.Close = true
-	// Retry requests up to 10 times, waiting a random amount of time between retries up to 30 seconds
-	failedRequests := 0
-	var resp *http.Response
-	for {
-		resp, err = http.DefaultClient.Do(req)
-		if err == nil {
-			break
-		}
-		if failedRequests >= maxFailedRequests {
-			return m, errors.Wrap(err, "Failed sending request")
-		}
-		failedRequests++
-		logger.Errf(ctx, "Failed sending request for id %d %d times. Retrying...", id, failedRequests)
-		select {
-		case <-time.After(time.Duration(failedRequests) * time.Duration(rand.Intn(3000)) * time.Millisecond):
-		case <-ctx.Done():
-			return m, ctx.Err()
-		}
+	resp, err := DefaultFetcher().Do(req)
+	if err != nil {
+		return m, err
 	}
-	root, err := htmlquery.Parse(resp.Body)
+	defer resp.Body.Close()
+	// Merged series redirect to the series they were merged into
+	if finalID := parseMULinkID(resp.Request.URL.String()); finalID > 0 && finalID != id {
+		return m, &MergedError{MUID: id, MergedInto: finalID}
+	}
+	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		resp.Body.Close()
 		return m, errors.Wrap(err, "Failed getting page")
 	}
-	resp.Body.Close()
+	saveSnapshot(ctx, Snapshot{Source: MangaUpdatesSourceName, Kind: SnapshotSeries, URL: req.URL.String(), MUID: id, Body: body, FetchedAt: time.Now().UTC()})
+	root, err := htmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return m, errors.Wrap(err, "Failed getting page")
+	}
+	return parseMUSeriesPage(root, id)
+}
+
+// parseMUSeriesPage parses the series page of id.
+func parseMUSeriesPage(root *html.Node, id int) (m MangaInfo, err error) {
 	seriesInfo := htmlquery.FindOne(root, "/html/body/div[2]/div[2]/div[2]/div[2]/div/div[2]/div[1]")
 	if seriesInfo == nil {
 		errorInfo := htmlquery.FindOne(root, "/html/body/div[2]/div[2]/div[2]/div[2]/div/div/div/div[2]/div")
 		if errorInfo != nil && strings.Contains(htmlquery.InnerText(errorInfo), "You specified an invalid series id.") {
 			return m, ErrInvalidMUID
+		} else if mergedInto := parseMUMergeNotice(errorInfo); mergedInto > 0 && mergedInto != id {
+			return m, &MergedError{MUID: id, MergedInto: mergedInto}
 		} else {
 			return m, errors.New("Failed to parse info page")
 		}
@@ -141,8 +290,7 @@ func GetAndParseMUMangaPage(ctx context.Context, id int) (m MangaInfo, err error
 	if mainTitleNode == nil {
 		return m, errors.New("Failed to parse mainTitle")
 	}
-	mainTitle := htmlquery.InnerText(mainTitleNode)
-	strings.TrimSpace(mainTitle)
+	mainTitle := strings.TrimSpace(htmlquery.InnerText(mainTitleNode))
 	if mainTitle == "" {
 		return m, errors.New("Got empty title")
 	}
@@ -170,19 +318,136 @@ func GetAndParseMUMangaPage(ctx context.Context, id int) (m MangaInfo, err error
 	if !strings.Contains(releaseText, "N/A") {
 		m.LatestRelease = strings.Split(releaseText, " by ")[0]
 	}
+	parseMUSeriesMetadata(root, &m)
 	return m, nil
 }
 
-func QueryLast2DaysOfMUReleases() ([]MangaRelease, error) {
-	html, err := htmlquery.LoadURL(muReleasesURL)
+// parseMUMergeNotice returns the id of the series linked from a notice that a series was merged, or 0 if errorInfo isn't one.
+func parseMUMergeNotice(errorInfo *html.Node) int {
+	if errorInfo == nil || !strings.Contains(strings.ToLower(htmlquery.InnerText(errorInfo)), "merged") {
+		return 0
+	}
+	link := htmlquery.FindOne(errorInfo, "//a[contains(@href, 'series.html?id=')]")
+	if link == nil {
+		return 0
+	}
+	return parseMULinkID(htmlquery.SelectAttr(link, "href"))
+}
+
+// muSeriesSections maps each section heading on a series page, like "Genre" or "Author(s)", to the node with its content.
+func muSeriesSections(root *html.Node) map[string]*html.Node {
+	sections := make(map[string]*html.Node)
+	for _, heading := range htmlquery.Find(root, "//div[@class=\"sCat\"]") {
+		content := heading.NextSibling
+		for content != nil && content.Type != html.ElementNode {
+			content = content.NextSibling
+		}
+		if content != nil {
+			sections[strings.TrimSpace(htmlquery.InnerText(heading))] = content
+		}
+	}
+	return sections
+}
+
+// muSectionLinks returns the text of every link in the section whose href contains hrefPart.
+func muSectionLinks(section *html.Node, hrefPart string) []string {
+	texts := make([]string, 0)
+	if section == nil {
+		return texts
+	}
+	for _, a := range htmlquery.Find(section, "//a") {
+		text := strings.TrimSpace(htmlquery.InnerText(a))
+		if text != "" && strings.Contains(htmlquery.SelectAttr(a, "href"), hrefPart) {
+			texts = append(texts, text)
+		}
+	}
+	return texts
+}
+
+// muSectionText returns the trimmed text of a section, or "" if it is missing or N/A.
+func muSectionText(section *html.Node) string {
+	if section == nil {
+		return ""
+	}
+	text := strings.TrimSpace(htmlquery.InnerText(section))
+	if text == "N/A" {
+		return ""
+	}
+	return text
+}
+
+// parseMUSeriesMetadata fills in the metadata of a series page that isn't needed for feeds to work.
+// Missing sections are skipped instead of failing the scrape.
+func parseMUSeriesMetadata(root *html.Node, m *MangaInfo) {
+	sections := muSeriesSections(root)
+	m.Genres = muSectionLinks(sections["Genre"], "genre=")
+	m.Categories = muSectionLinks(sections["Categories"], "category=")
+	m.Authors = muSectionLinks(sections["Author(s)"], "authors.html")
+	m.Artists = muSectionLinks(sections["Artist(s)"], "authors.html")
+	m.Type = muSectionText(sections["Type"])
+	m.Status = muSectionText(sections["Status in Country of Origin"])
+	if year, err := strconv.Atoi(muSectionText(sections["Year"])); err == nil {
+		m.Year = year
+	}
+	if image := sections["Image"]; image != nil {
+		if img := htmlquery.FindOne(image, "//img"); img != nil {
+			m.CoverURL = htmlquery.SelectAttr(img, "src")
+		}
+	}
+}
+
+// loadMUPage fetches and parses a MangaUpdates page, revalidating the last copy of it instead if cached is set.
+// Pages that were downloaded again are saved as snap with the rest of its fields filled in, unless it has no Kind.
+func loadMUPage(ctx context.Context, pageURL string, cached bool, snap Snapshot) (*html.Node, error) {
+	get := DefaultFetcher().Get
+	if cached {
+		get = DefaultFetcher().GetCached
+	}
+	resp, err := get(ctx, pageURL)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Failed getting %s, got status %s", pageURL, resp.Status)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed reading page")
+	}
+	if snap.Kind != "" && resp.Header.Get(CacheHeader) == "" {
+		snap.Source, snap.URL, snap.Body, snap.FetchedAt = MangaUpdatesSourceName, pageURL, body, time.Now().UTC()
+		saveSnapshot(ctx, snap)
 	}
+	root, err := htmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed parsing page")
+	}
+	return root, nil
+}
+
+func QueryLast2DaysOfMUReleases(ctx context.Context) ([]MangaRelease, error) {
+	// The releases page is polled far more often than it changes, so only download it again if it has
+	html, err := loadMUPage(ctx, muReleasesURL, true, Snapshot{Kind: SnapshotRecentReleases})
+	if err != nil {
+		return nil, err
+	}
+	return parseMURecentReleases(html, time.Now())
+}
+
+// parseMURecentReleases parses the tables of today's and yesterday's releases on the releases page, as of now.
+func parseMURecentReleases(html *html.Node, now time.Time) ([]MangaRelease, error) {
 	todaysReleasesHTML := htmlquery.FindOne(html, "//*[@id=\"main_content\"]/div[2]/div/div[2]/div")
 	if todaysReleasesHTML == nil || todaysReleasesHTML.FirstChild == nil {
 		return nil, errors.New("Failed parsing for today releases")
 	}
-	todaysReleases, err := parseMUDailyReleases(todaysReleasesHTML.FirstChild)
+	// Fall back to the days relative to now if MangaUpdates stops labeling the tables
+	today := now.UTC().Truncate(24 * time.Hour)
+	todaysDay, found := findMUReleaseDay(todaysReleasesHTML)
+	if !found {
+		todaysDay = today
+	}
+	todaysReleases, err := parseMUDailyReleases(todaysReleasesHTML.FirstChild, todaysDay, now)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -190,45 +455,166 @@ func QueryLast2DaysOfMUReleases() ([]MangaRelease, error) {
 	if yesterdaysReleasesHTML == nil || yesterdaysReleasesHTML.FirstChild == nil {
 		return nil, errors.New("Failed parsing for yesterdays releases")
 	}
-	yesterdaysReleases, err := parseMUDailyReleases(yesterdaysReleasesHTML.FirstChild)
+	yesterdaysDay, found := findMUReleaseDay(yesterdaysReleasesHTML)
+	if !found {
+		yesterdaysDay = today.AddDate(0, 0, -1)
+	}
+	yesterdaysReleases, err := parseMUDailyReleases(yesterdaysReleasesHTML.FirstChild, yesterdaysDay, now)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 	return append(todaysReleases, yesterdaysReleases...), nil
 }
 
-func PollMUForReleases(ctx context.Context, freq time.Duration) <-chan []MangaRelease {
-	out := make(chan []MangaRelease)
-	timer := time.NewTicker(freq)
-	pollFunc := func() {
-		start := time.Now()
-		releases, err := QueryLast2DaysOfMUReleases()
-		if err != nil {
-			logger.Errf(ctx, "Failed to get releases from MangaUpdates! %+v", err)
-			return
-		}
-		logger.Dbgf(ctx, "Scraped %d mangaupdates releases in %s", len(releases), time.Since(start).String())
+// maxArchivePages stops QueryMUReleasesOn from paging forever if MangaUpdates ignores the page parameter
+const maxArchivePages = 50
+
+// QueryMUReleasesOn scrapes every page of the MangaUpdates release archive for the given day.
+func QueryMUReleasesOn(ctx context.Context, day time.Time) ([]MangaRelease, error) {
+	allReleases := make([]MangaRelease, 0)
+	var lastPageFirstRelease MangaRelease
+	for page := 1; page <= maxArchivePages; page++ {
 		select {
 		case <-ctx.Done():
-			return
-		case out <- releases:
-		}
-	}
-	go func() {
-		defer func() {
-			close(out)
-			timer.Stop()
-		}()
-		// Poll immediately on startup instead of waiting for poll duration
-		pollFunc()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-timer.C:
-				pollFunc()
-			}
+			return nil, ctx.Err()
+		default:
+		}
+		html, err := loadMUPage(ctx, fmt.Sprintf(muReleasesArchiveURLFormat, day.Format("2006-01-02"), page), false, Snapshot{Kind: SnapshotArchiveReleases, Day: day})
+		if err != nil {
+			return nil, err
+		}
+		releases, err := parseMUArchiveReleases(html, day, time.Now())
+		if errors.Cause(err) == errNoArchivedReleases && page > 1 {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if len(releases) == 0 {
+			break
 		}
-	}()
-	return out
+		// Compare without CreatedAt since it differs between pages
+		first := releases[0]
+		if first.MUID == lastPageFirstRelease.MUID && first.Release == lastPageFirstRelease.Release && first.Translators == lastPageFirstRelease.Translators {
+			break
+		}
+		lastPageFirstRelease = first
+		allReleases = append(allReleases, releases...)
+	}
+	return allReleases, nil
+}
+
+// SearchMUSeries scrapes the MangaUpdates series search for title, returning every series listed.
+func SearchMUSeries(ctx context.Context, title string) ([]SeriesMatch, error) {
+	html, err := loadMUPage(ctx, fmt.Sprintf(muSearchURLFormat, url.QueryEscape(title)), false, Snapshot{})
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]SeriesMatch, 0)
+	for _, a := range htmlquery.Find(html, "//*[@id=\"main_content\"]//a[contains(@href, 'series.html?id=')]") {
+		muid := parseMULinkID(htmlquery.SelectAttr(a, "href"))
+		name := strings.TrimSpace(htmlquery.InnerText(a))
+		if muid > 0 && name != "" {
+			matches = append(matches, SeriesMatch{MUID: muid, Title: name})
+		}
+	}
+	return matches, nil
+}
+
+// errNoArchivedReleases means a page of the release archive has no table of releases, which past the first page means there are no more
+const errNoArchivedReleases lib.SentinelError = "No archived releases"
+
+// parseMUArchiveReleases parses a page of the release archive for day, scraped at now.
+func parseMUArchiveReleases(html *html.Node, day, now time.Time) ([]MangaRelease, error) {
+	releasesHTML := htmlquery.FindOne(html, "//*[@id=\"main_content\"]//div[contains(@class,\"col-6\")]/..")
+	if releasesHTML == nil || releasesHTML.FirstChild == nil {
+		return nil, errors.Wrapf(errNoArchivedReleases, "Failed parsing archived releases for %s", day.Format("2006-01-02"))
+	}
+	releases, err := parseMUDailyReleases(releasesHTML.FirstChild, day, now)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return releases, nil
+}
+
+// MangaUpdates is the Source for mangaupdates.com, registered under MangaUpdatesSourceName.
+type MangaUpdates struct{}
+
+const MangaUpdatesSourceName = "mangaupdates"
+
+// SequentialIDs marks the legacy series.html?id= ids as handed out in order, unlike the API's series_ids.
+func (MangaUpdates) SequentialIDs() {}
+
+func init() {
+	RegisterSource(MangaUpdates{})
+}
+
+func (MangaUpdates) Name() string { return MangaUpdatesSourceName }
+
+func (MangaUpdates) QueryRecentReleases(ctx context.Context) ([]MangaRelease, error) {
+	return QueryLast2DaysOfMUReleases(ctx)
+}
+
+func (MangaUpdates) GetMangaInfo(ctx context.Context, id int) (MangaInfo, error) {
+	return GetAndParseMUMangaPage(ctx, id)
+}
+
+func (MangaUpdates) SeriesURL(id int) string { return GetMUPageURL(id) }
+
+// ParseSeriesURL reads the id of legacy series.html?id= links. The newer URL forms link by the API's series_id instead,
+// so their page is fetched for the legacy link it's known by.
+func (MangaUpdates) ParseSeriesURL(ctx context.Context, u *url.URL) (int, error) {
+	if id := parseLegacyMUSeriesURL(u); id > 0 {
+		return id, nil
+	}
+	seriesID := parseMUSeriesURL(u)
+	if seriesID < 1 {
+		return 0, ErrNotSeriesURL
+	}
+	links, err := getMUSeriesPageLinks(ctx, DefaultFetcher(), getMUSeriesURL(seriesID))
+	if err != nil {
+		return 0, err
+	}
+	for _, link := range links {
+		if id := parseLegacyMUSeriesURL(link); id > 0 {
+			return id, nil
+		}
+	}
+	return 0, ErrNotSeriesURL
+}
+
+func (MangaUpdates) QueryReleasesOn(ctx context.Context, day time.Time) ([]MangaRelease, error) {
+	return QueryMUReleasesOn(ctx, day)
+}
+
+func (MangaUpdates) SearchSeries(ctx context.Context, title string) ([]SeriesMatch, error) {
+	return SearchMUSeries(ctx, title)
+}
+
+func (MangaUpdates) ParseReleasesSnapshot(snap Snapshot) ([]MangaRelease, error) {
+	root, err := htmlquery.Parse(bytes.NewReader(snap.Body))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed parsing snapshot")
+	}
+	switch snap.Kind {
+	case SnapshotRecentReleases:
+		return parseMURecentReleases(root, snap.FetchedAt)
+	case SnapshotArchiveReleases:
+		releases, err := parseMUArchiveReleases(root, snap.Day, snap.FetchedAt)
+		if errors.Cause(err) == errNoArchivedReleases {
+			// Pages past the last one of the day are snapshotted too
+			return nil, nil
+		}
+		return releases, err
+	}
+	return nil, errors.Errorf("%s snapshots don't have releases", snap.Kind)
+}
+
+func (MangaUpdates) ParseSeriesSnapshot(snap Snapshot) (MangaInfo, error) {
+	root, err := htmlquery.Parse(bytes.NewReader(snap.Body))
+	if err != nil {
+		return MangaInfo{}, errors.Wrap(err, "Failed parsing snapshot")
+	}
+	m, err := parseMUSeriesPage(root, snap.MUID)
+	m.ScrapedAt = snap.FetchedAt
+	return m, err
 }
@@ -0,0 +1,312 @@
+package scrape
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const MUAPIDefaultURL = "https://api.mangaupdates.com/v1"
+const MangaUpdatesAPISourceName = "mangaupdates-api"
+
+// muAPIPerPage is the largest page size the MangaUpdates API accepts for searches
+const muAPIPerPage = 100
+
+// MangaUpdatesAPI is a Source backed by the structured MangaUpdates JSON API instead of the HTML pages.
+// IDs used by this Source are the API's series_id, not the legacy series.html?id= ids, so a db populated by one can't be used with the other.
+type MangaUpdatesAPI struct {
+	baseURL string
+	fetcher *Fetcher
+}
+
+// NewMangaUpdatesAPI creates a MangaUpdatesAPI client against baseURL, using DefaultFetcher if fetcher is nil.
+func NewMangaUpdatesAPI(baseURL string, fetcher *Fetcher) *MangaUpdatesAPI {
+	return &MangaUpdatesAPI{baseURL: strings.TrimSuffix(baseURL, "/"), fetcher: fetcher}
+}
+
+// fetch returns the Fetcher requests go through, looking up DefaultFetcher each time so SetDefaultFetcher applies to registered Sources.
+func (m *MangaUpdatesAPI) fetch() *Fetcher {
+	if m.fetcher == nil {
+		return DefaultFetcher()
+	}
+	return m.fetcher
+}
+
+func init() {
+	RegisterSource(NewMangaUpdatesAPI(MUAPIDefaultURL, nil))
+}
+
+type muAPISeries struct {
+	SeriesID   int    `json:"series_id"`
+	Title      string `json:"title"`
+	Associated []struct {
+		Title string `json:"title"`
+	} `json:"associated"`
+	LatestChapter int `json:"latest_chapter"`
+	Genres        []struct {
+		Genre string `json:"genre"`
+	} `json:"genres"`
+	Categories []struct {
+		Category string `json:"category"`
+	} `json:"categories"`
+	Authors []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"authors"`
+	Status string `json:"status"`
+	Type   string `json:"type"`
+	Year   string `json:"year"`
+	Image  struct {
+		URL struct {
+			Original string `json:"original"`
+		} `json:"url"`
+	} `json:"image"`
+}
+
+type muAPIRelease struct {
+	Record struct {
+		ID      int    `json:"id"`
+		Title   string `json:"title"`
+		Volume  string `json:"volume"`
+		Chapter string `json:"chapter"`
+		Groups  []struct {
+			Name    string `json:"name"`
+			GroupID int    `json:"group_id"`
+		} `json:"groups"`
+		ReleaseDate string `json:"release_date"`
+	} `json:"record"`
+	Metadata struct {
+		Series struct {
+			SeriesID int    `json:"series_id"`
+			Title    string `json:"title"`
+		} `json:"series"`
+	} `json:"metadata"`
+}
+
+type muAPIReleaseSearchRequest struct {
+	StartDate       string `json:"start_date,omitempty"`
+	EndDate         string `json:"end_date,omitempty"`
+	OrderBy         string `json:"orderby"`
+	IncludeMetadata bool   `json:"include_metadata"`
+	Page            int    `json:"page"`
+	PerPage         int    `json:"perpage"`
+}
+
+type muAPIReleaseSearchResponse struct {
+	TotalHits int            `json:"total_hits"`
+	Page      int            `json:"page"`
+	PerPage   int            `json:"per_page"`
+	Results   []muAPIRelease `json:"results"`
+}
+
+type muAPISeriesSearchRequest struct {
+	Search  string `json:"search"`
+	PerPage int    `json:"perpage"`
+}
+
+type muAPISeriesSearchResponse struct {
+	Results []struct {
+		Record struct {
+			SeriesID int    `json:"series_id"`
+			Title    string `json:"title"`
+		} `json:"record"`
+		HitTitle string `json:"hit_title"`
+	} `json:"results"`
+}
+
+func (m *MangaUpdatesAPI) Name() string { return MangaUpdatesAPISourceName }
+
+// SeriesURL returns the newer MangaUpdates URL form, which encodes the series_id in base 36.
+func (m *MangaUpdatesAPI) SeriesURL(id int) string { return getMUSeriesURL(id) }
+
+// ParseSeriesURL reads the series_id of the newer MangaUpdates URL forms, like www.mangaupdates.com/series/<base 36 id>/<slug>
+// and api.mangaupdates.com/v1/series/<id>. Legacy series.html?id= links use other ids, so their page is fetched for the newer URL it redirects to.
+func (m *MangaUpdatesAPI) ParseSeriesURL(ctx context.Context, u *url.URL) (int, error) {
+	if id := parseMUSeriesURL(u); id > 0 {
+		return id, nil
+	}
+	legacyID := parseLegacyMUSeriesURL(u)
+	if legacyID < 1 {
+		return 0, ErrNotSeriesURL
+	}
+	links, err := getMUSeriesPageLinks(ctx, m.fetch(), GetMUPageURL(legacyID))
+	if err != nil {
+		return 0, err
+	}
+	for _, link := range links {
+		if id := parseMUSeriesURL(link); id > 0 {
+			return id, nil
+		}
+	}
+	return 0, ErrNotSeriesURL
+}
+
+// GetMangaInfo looks up a series and its associated names.
+func (m *MangaUpdatesAPI) GetMangaInfo(ctx context.Context, id int) (mi MangaInfo, err error) {
+	series := muAPISeries{}
+	if err := m.doJSON(ctx, http.MethodGet, fmt.Sprintf("/series/%d", id), nil, &series); err != nil {
+		return mi, err
+	}
+	// Merged series are answered with the series they were merged into
+	if series.SeriesID != 0 && series.SeriesID != id {
+		return mi, &MergedError{MUID: id, MergedInto: series.SeriesID}
+	}
+	title := strings.TrimSpace(series.Title)
+	if title == "" {
+		return mi, errors.New("Got empty title")
+	}
+	mi.MUID = id
+	mi.DisplayTitle = title
+	mi.Titles = append(mi.Titles, strings.ToLower(title))
+	for _, a := range series.Associated {
+		if t := strings.TrimSpace(a.Title); t != "" {
+			mi.Titles = append(mi.Titles, strings.ToLower(t))
+		}
+	}
+	if series.LatestChapter > 0 {
+		mi.LatestRelease = fmt.Sprintf("c.%d", series.LatestChapter)
+	}
+	for _, g := range series.Genres {
+		mi.Genres = append(mi.Genres, g.Genre)
+	}
+	for _, c := range series.Categories {
+		mi.Categories = append(mi.Categories, c.Category)
+	}
+	for _, a := range series.Authors {
+		if a.Type == "Artist" {
+			mi.Artists = append(mi.Artists, a.Name)
+		} else {
+			mi.Authors = append(mi.Authors, a.Name)
+		}
+	}
+	mi.Status = series.Status
+	mi.Type = series.Type
+	mi.Year, _ = strconv.Atoi(series.Year)
+	mi.CoverURL = series.Image.URL.Original
+	return mi, nil
+}
+
+// SearchSeries searches series by title. Series found through one of their associated names are listed under both.
+func (m *MangaUpdatesAPI) SearchSeries(ctx context.Context, title string) ([]SeriesMatch, error) {
+	resp := muAPISeriesSearchResponse{}
+	if err := m.doJSON(ctx, http.MethodPost, "/series/search", muAPISeriesSearchRequest{Search: title, PerPage: muAPIPerPage}, &resp); err != nil {
+		return nil, err
+	}
+	matches := make([]SeriesMatch, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		matches = append(matches, SeriesMatch{MUID: r.Record.SeriesID, Title: r.Record.Title})
+		if r.HitTitle != "" && r.HitTitle != r.Record.Title {
+			matches = append(matches, SeriesMatch{MUID: r.Record.SeriesID, Title: r.HitTitle})
+		}
+	}
+	return matches, nil
+}
+
+// QueryRecentReleases returns the releases from today and yesterday, mirroring the HTML releases page.
+func (m *MangaUpdatesAPI) QueryRecentReleases(ctx context.Context) ([]MangaRelease, error) {
+	now := time.Now()
+	return m.SearchReleases(ctx, now.AddDate(0, 0, -1), now)
+}
+
+func (m *MangaUpdatesAPI) QueryReleasesOn(ctx context.Context, day time.Time) ([]MangaRelease, error) {
+	return m.SearchReleases(ctx, day, day)
+}
+
+// SearchReleases pages through every release dated between start and end inclusive.
+func (m *MangaUpdatesAPI) SearchReleases(ctx context.Context, start, end time.Time) ([]MangaRelease, error) {
+	now := time.Now()
+	releases := make([]MangaRelease, 0)
+	req := muAPIReleaseSearchRequest{
+		StartDate:       start.Format("2006-01-02"),
+		EndDate:         end.Format("2006-01-02"),
+		OrderBy:         "date",
+		IncludeMetadata: true,
+		PerPage:         muAPIPerPage,
+	}
+	for req.Page = 1; ; req.Page++ {
+		resp := muAPIReleaseSearchResponse{}
+		if err := m.doJSON(ctx, http.MethodPost, "/releases/search", req, &resp); err != nil {
+			return nil, err
+		}
+		for _, r := range resp.Results {
+			releases = append(releases, r.toMangaRelease(now))
+		}
+		if len(resp.Results) == 0 || req.Page*req.PerPage >= resp.TotalHits {
+			return releases, nil
+		}
+	}
+}
+
+func (r muAPIRelease) toMangaRelease(now time.Time) MangaRelease {
+	title := r.Metadata.Series.Title
+	if title == "" {
+		title = r.Record.Title
+	}
+	release := make([]string, 0, 2)
+	if r.Record.Volume != "" {
+		release = append(release, "v."+r.Record.Volume)
+	}
+	if r.Record.Chapter != "" {
+		release = append(release, "c."+r.Record.Chapter)
+	}
+	releasedAt, err := time.Parse("2006-01-02", r.Record.ReleaseDate)
+	if err != nil {
+		releasedAt = now
+	}
+	groupNames := make([]string, 0, len(r.Record.Groups))
+	groups := make([]ScanlationGroup, 0, len(r.Record.Groups))
+	for _, g := range r.Record.Groups {
+		groupNames = append(groupNames, g.Name)
+		groups = append(groups, ScanlationGroup{ID: g.GroupID, Name: g.Name})
+	}
+	return MangaRelease{
+		MUID:        r.Metadata.Series.SeriesID,
+		Title:       strings.ToLower(strings.TrimSpace(title)),
+		Release:     strings.Join(release, " "),
+		Translators: strings.Join(groupNames, " & "),
+		Groups:      groups,
+		CreatedAt:   now,
+		ReleasedAt:  releasedAt,
+	}
+}
+
+func (m *MangaUpdatesAPI) doJSON(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return errors.Wrap(err, "Failed encoding request body")
+		}
+	}
+	req, err := http.NewRequest(method, m.baseURL+path, &reqBody)
+	if err != nil {
+		return errors.Wrap(err, "Failed creating request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := m.fetch().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrInvalidMUID
+	case resp.StatusCode != http.StatusOK:
+		return errors.Errorf("MangaUpdates API %s %s returned status %d", method, path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.Wrapf(err, "Failed decoding MangaUpdates API %s %s response", method, path)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package scrape
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// newMUAPITestServer serves the MangaUpdates API responses recorded under testdata/mangaupdates_api,
+// recording the body of each release search it's sent in searches.
+func newMUAPITestServer(t *testing.T, searches *[]muAPIReleaseSearchRequest) (*httptest.Server, *MangaUpdatesAPI) {
+	fixture := func(w http.ResponseWriter, status int, name string) {
+		body, err := ioutil.ReadFile(filepath.Join("testdata", "mangaupdates_api", name))
+		if err != nil {
+			t.Fatalf("Failed reading fixture %s: %v", name, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(body)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/releases/search", func(w http.ResponseWriter, r *http.Request) {
+		req := muAPIReleaseSearchRequest{}
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&req) != nil {
+			t.Errorf("Unexpected release search %s %s", r.Method, r.URL)
+		}
+		*searches = append(*searches, req)
+		fixture(w, http.StatusOK, "releases_search.json")
+	})
+	mux.HandleFunc("/v1/series/55099564912", func(w http.ResponseWriter, r *http.Request) {
+		fixture(w, http.StatusOK, "series_55099564912.json")
+	})
+	// The API answers series merged into another with the series they were merged into
+	mux.HandleFunc("/v1/series/71883934511", func(w http.ResponseWriter, r *http.Request) {
+		fixture(w, http.StatusOK, "series_merged_71883934511.json")
+	})
+	mux.HandleFunc("/v1/series/404", func(w http.ResponseWriter, r *http.Request) {
+		fixture(w, http.StatusNotFound, "series_not_found.json")
+	})
+	srv := httptest.NewServer(mux)
+	fetcher := NewFetcher(FetcherOptions{Transport: srv.Client().Transport, RequestsPerSecond: 1000, MaxRetries: -1})
+	return srv, NewMangaUpdatesAPI(srv.URL+"/v1", fetcher)
+}
+
+func TestMangaUpdatesAPISearchReleases(t *testing.T) {
+	searches := make([]muAPIReleaseSearchRequest, 0)
+	srv, api := newMUAPITestServer(t, &searches)
+	defer srv.Close()
+
+	start, end := time.Date(2023, 12, 7, 0, 0, 0, 0, time.UTC), time.Date(2023, 12, 8, 0, 0, 0, 0, time.UTC)
+	releases, err := api.SearchReleases(context.Background(), start, end)
+	if err != nil {
+		t.Fatalf("SearchReleases failed: %+v", err)
+	}
+	wantSearches := []muAPIReleaseSearchRequest{
+		{StartDate: "2023-12-07", EndDate: "2023-12-08", OrderBy: "date", IncludeMetadata: true, Page: 1, PerPage: muAPIPerPage},
+	}
+	if !reflect.DeepEqual(searches, wantSearches) {
+		t.Errorf("Searched releases with %+v, want %+v", searches, wantSearches)
+	}
+	want := []MangaRelease{
+		{
+			MUID:        55099564912,
+			Title:       "one piece",
+			Release:     "c.1101",
+			Translators: "TCB Scans",
+			Groups:      []ScanlationGroup{{ID: 8451265543, Name: "TCB Scans"}},
+			ReleasedAt:  time.Date(2023, 12, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			MUID:        12519003591,
+			Title:       "kaguya-sama wa kokurasetai: tensai-tachi no renai zunousen",
+			Release:     "v.28 c.275-276",
+			Translators: "Mangadex Group A & Group B",
+			Groups:      []ScanlationGroup{{ID: 15687233091, Name: "Mangadex Group A"}, {ID: 34108212283, Name: "Group B"}},
+			ReleasedAt:  time.Date(2023, 12, 7, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	if len(releases) != len(want) {
+		t.Fatalf("Got %d releases, want %d", len(releases), len(want))
+	}
+	for i := range want {
+		got := releases[i]
+		if got.CreatedAt.IsZero() {
+			t.Errorf("Release %d has no CreatedAt", i)
+		}
+		got.CreatedAt = time.Time{}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("Release %d is %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestMangaUpdatesAPIGetMangaInfo(t *testing.T) {
+	srv, api := newMUAPITestServer(t, &[]muAPIReleaseSearchRequest{})
+	defer srv.Close()
+	ctx := context.Background()
+
+	got, err := api.GetMangaInfo(ctx, 55099564912)
+	if err != nil {
+		t.Fatalf("GetMangaInfo failed: %+v", err)
+	}
+	want := MangaInfo{
+		MUID:          55099564912,
+		DisplayTitle:  "One Piece",
+		Titles:        []string{"one piece", "one piece", "ван пис", "원피스"},
+		LatestRelease: "c.1101",
+		Genres:        []string{"Action", "Adventure", "Comedy"},
+		Categories:    []string{"Pirate/s", "Devil Fruit/s"},
+		Authors:       []string{"ODA Eiichiro"},
+		Artists:       []string{"ODA Eiichiro"},
+		Status:        "107 Volumes (Ongoing)",
+		Type:          "Manga",
+		Year:          1997,
+		CoverURL:      "https://cdn.mangaupdates.com/image/i456797.jpg",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMangaInfo returned %+v, want %+v", got, want)
+	}
+
+	if _, err := api.GetMangaInfo(ctx, 71883934511); MergedInto(err) != 12519003591 {
+		t.Errorf("GetMangaInfo of a merged series returned err %v, want it merged into 12519003591", err)
+	}
+	if _, err := api.GetMangaInfo(ctx, 404); errors.Cause(err) != ErrInvalidMUID {
+		t.Errorf("GetMangaInfo of a missing series returned err %v, want %v", err, ErrInvalidMUID)
+	}
+}
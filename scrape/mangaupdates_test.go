@@ -0,0 +1,88 @@
+package scrape
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// testServerTransport sends every request to srv, whatever host it was for, so pages can be served under their real URLs.
+type testServerTransport struct {
+	srv *httptest.Server
+}
+
+func (t testServerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	srvURL, _ := url.Parse(t.srv.URL)
+	sent := *req
+	sentURL := *req.URL
+	sentURL.Scheme, sentURL.Host = srvURL.Scheme, srvURL.Host
+	sent.URL = &sentURL
+	resp, err := t.srv.Client().Transport.RoundTrip(&sent)
+	if err == nil {
+		resp.Request = req
+	}
+	return resp, err
+}
+
+func TestParseSeriesURL(t *testing.T) {
+	mux := http.NewServeMux()
+	// MangaUpdates redirects legacy links to the newer URL form
+	mux.HandleFunc("/series.html", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "33" {
+			http.NotFound(w, r)
+			return
+		}
+		http.Redirect(w, r, "https://www.mangaupdates.com/series/pb8uwds/one-piece", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/series/pb8uwds", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/series/pb8uwds/one-piece", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/series/pb8uwds/one-piece", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadFile(filepath.Join("testdata", "mangaupdates", "series_pb8uwds.html"))
+		if err != nil {
+			t.Fatalf("Failed reading fixture: %v", err)
+		}
+		w.Write(body)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	fetcher := NewFetcher(FetcherOptions{Transport: testServerTransport{srv}, RequestsPerSecond: 1000, MaxRetries: -1})
+	defer SetDefaultFetcher(DefaultFetcher())
+	SetDefaultFetcher(fetcher)
+
+	tests := []struct {
+		name   string
+		source SeriesURLParser
+		url    string
+		want   int
+		err    error
+	}{
+		{"html legacy", MangaUpdates{}, "https://www.mangaupdates.com/series.html?id=33", 33, nil},
+		{"html new", MangaUpdates{}, "https://www.mangaupdates.com/series/pb8uwds/one-piece", 33, nil},
+		{"html new without slug", MangaUpdates{}, "https://mangaupdates.com/series/pb8uwds", 33, nil},
+		{"html api", MangaUpdates{}, "https://api.mangaupdates.com/v1/series/55099564912", 33, nil},
+		{"html not a series", MangaUpdates{}, "https://www.mangaupdates.com/releases.html", 0, ErrNotSeriesURL},
+		{"html other host", MangaUpdates{}, "https://example.com/series.html?id=33", 0, ErrNotSeriesURL},
+		{"api legacy", NewMangaUpdatesAPI(MUAPIDefaultURL, fetcher), "https://www.mangaupdates.com/series.html?id=33", 55099564912, nil},
+		{"api new", NewMangaUpdatesAPI(MUAPIDefaultURL, fetcher), "https://www.mangaupdates.com/series/pb8uwds/one-piece", 55099564912, nil},
+		{"api api", NewMangaUpdatesAPI(MUAPIDefaultURL, fetcher), "https://api.mangaupdates.com/v1/series/55099564912", 55099564912, nil},
+		{"api missing legacy", NewMangaUpdatesAPI(MUAPIDefaultURL, fetcher), "https://www.mangaupdates.com/series.html?id=404", 0, ErrNotSeriesURL},
+		{"api not a series", NewMangaUpdatesAPI(MUAPIDefaultURL, fetcher), "https://www.mangaupdates.com/releases.html", 0, ErrNotSeriesURL},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatalf("%s: Failed parsing %s: %v", tt.name, tt.url, err)
+		}
+		got, err := tt.source.ParseSeriesURL(context.Background(), u)
+		if got != tt.want || errors.Cause(err) != tt.err {
+			t.Errorf("%s: ParseSeriesURL(%s) = %d, %v, want %d, %v", tt.name, tt.url, got, err, tt.want, tt.err)
+		}
+	}
+}
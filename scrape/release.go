@@ -0,0 +1,107 @@
+package scrape
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ReleaseParserVersion is bumped whenever ParseRelease parses release text differently,
+// so the releases parsed by an older version can be found and parsed again.
+const ReleaseParserVersion = 2
+
+// ReleaseInfo is the structured form of release text such as "v.3 c.12-14" or "c.105.5".
+// Fields are nil when the release text doesn't mention them.
+type ReleaseInfo struct {
+	Volume *int `db:"volume" json:"volume,omitempty"`
+	// ChapterStart and ChapterEnd are decimal numbers as written, like 105.5, so .5 and .05 stay apart
+	ChapterStart *json.Number `db:"chapter_start" json:"chapter_start,omitempty"`
+	ChapterEnd   *json.Number `db:"chapter_end" json:"chapter_end,omitempty"`
+	Oneshot      bool         `db:"is_oneshot" json:"oneshot,omitempty"`
+	Extra        bool         `db:"is_extra" json:"extra,omitempty"`
+	Omake        bool         `db:"is_omake" json:"omake,omitempty"`
+}
+
+var (
+	volumeRegexp  = regexp.MustCompile(`\bv(?:ol)?\.?\s*(\d+)`)
+	chapterRegexp = regexp.MustCompile(`\bc(?:h)?\.?\s*(\d+(?:\.\d+)?)(?:\s*-\s*(\d+(?:\.\d+)?))?`)
+	oneshotRegexp = regexp.MustCompile(`\bone\s*-?\s*shot\b`)
+	extraRegexp   = regexp.MustCompile(`\bextras?\b`)
+	omakeRegexp   = regexp.MustCompile(`\bomake\b`)
+)
+
+// ParseRelease parses release text as written on MangaUpdates into a ReleaseInfo.
+// Text it doesn't understand results in an empty ReleaseInfo rather than an error.
+func ParseRelease(release string) (ri ReleaseInfo) {
+	release = strings.ToLower(release)
+	if m := volumeRegexp.FindStringSubmatch(release); m != nil {
+		ri.Volume = atoiPtr(m[1])
+	}
+	if m := chapterRegexp.FindStringSubmatch(release); m != nil {
+		ri.ChapterStart = chapterPtr(m[1])
+		ri.ChapterEnd = chapterPtr(m[2])
+		if ri.ChapterEnd == nil {
+			ri.ChapterEnd = ri.ChapterStart
+		}
+	}
+	ri.Oneshot = oneshotRegexp.MatchString(release)
+	ri.Extra = extraRegexp.MatchString(release)
+	ri.Omake = omakeRegexp.MatchString(release)
+	return ri
+}
+
+// SortKey returns a string that sorts releases of the same series in chapter order, by the chapter they start at
+// and then the one they end at, so c.10.5-11 comes between c.10 and c.11.
+func (ri ReleaseInfo) SortKey() string {
+	volume := 0
+	if ri.Volume != nil {
+		volume = *ri.Volume
+	}
+	extra := 0
+	if ri.Extra || ri.Omake {
+		extra = 1
+	}
+	return fmt.Sprintf("%s.%s.%06d.%d", chapterSortKey(ri.ChapterStart), chapterSortKey(ri.ChapterEnd), volume, extra)
+}
+
+// chapterFractionDigits is how many digits after the decimal point of a chapter SortKey tells apart
+const chapterFractionDigits = 6
+
+// chapterSortKey pads c's whole and fractional parts to a fixed width, so the keys of chapters sort like the chapters.
+func chapterSortKey(c *json.Number) string {
+	whole, fraction := "0", ""
+	if c != nil {
+		parts := strings.SplitN(c.String(), ".", 2)
+		whole = strings.TrimLeft(parts[0], "0")
+		if len(parts) == 2 {
+			fraction = parts[1]
+		}
+	}
+	if len(fraction) > chapterFractionDigits {
+		fraction = fraction[:chapterFractionDigits]
+	}
+	return fmt.Sprintf("%09s%s", whole, fraction+strings.Repeat("0", chapterFractionDigits-len(fraction)))
+}
+
+// chapterPtr reads a chapter number as written, without leading zeros, or returns nil if s is empty.
+func chapterPtr(s string) *json.Number {
+	if s == "" {
+		return nil
+	}
+	parts := strings.SplitN(s, ".", 2)
+	if parts[0] = strings.TrimLeft(parts[0], "0"); parts[0] == "" {
+		parts[0] = "0"
+	}
+	c := json.Number(strings.Join(parts, "."))
+	return &c
+}
+
+func atoiPtr(s string) *int {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return nil
+	}
+	return &i
+}
@@ -0,0 +1,68 @@
+package scrape
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseRelease(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	chapter := func(c string) *json.Number {
+		n := json.Number(c)
+		return &n
+	}
+	tests := []struct {
+		release string
+		want    ReleaseInfo
+	}{
+		{"c.12", ReleaseInfo{ChapterStart: chapter("12"), ChapterEnd: chapter("12")}},
+		{"v.3 c.12-14", ReleaseInfo{Volume: intPtr(3), ChapterStart: chapter("12"), ChapterEnd: chapter("14")}},
+		{"c.105.5", ReleaseInfo{ChapterStart: chapter("105.5"), ChapterEnd: chapter("105.5")}},
+		{"c.10.05", ReleaseInfo{ChapterStart: chapter("10.05"), ChapterEnd: chapter("10.05")}},
+		{"c.10.5-11", ReleaseInfo{ChapterStart: chapter("10.5"), ChapterEnd: chapter("11")}},
+		{"c.10-11.5", ReleaseInfo{ChapterStart: chapter("10"), ChapterEnd: chapter("11.5")}},
+		{"Vol.2 Ch. 007", ReleaseInfo{Volume: intPtr(2), ChapterStart: chapter("7"), ChapterEnd: chapter("7")}},
+		{"c.0", ReleaseInfo{ChapterStart: chapter("0"), ChapterEnd: chapter("0")}},
+		{"v.5", ReleaseInfo{Volume: intPtr(5)}},
+		{"Oneshot", ReleaseInfo{Oneshot: true}},
+		{"c.20 + extras", ReleaseInfo{ChapterStart: chapter("20"), ChapterEnd: chapter("20"), Extra: true}},
+		{"v.1 omake", ReleaseInfo{Volume: intPtr(1), Omake: true}},
+		{"complete", ReleaseInfo{}},
+	}
+	for _, tt := range tests {
+		if got := ParseRelease(tt.release); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseRelease(%q) = %+v, want %+v", tt.release, got, tt.want)
+		}
+	}
+}
+
+func TestReleaseSortKey(t *testing.T) {
+	tests := []struct {
+		name string
+		// releases are in the order their SortKeys should sort in
+		releases []string
+	}{
+		{"whole chapters", []string{"c.2", "c.10", "c.100"}},
+		{"decimal chapters", []string{"c.10", "c.10.05", "c.10.5", "c.11"}},
+		{"ranges by start chapter", []string{"c.10", "c.10-11", "c.10.5-11", "c.11", "c.11-13"}},
+		{"extras after their chapter", []string{"c.20", "c.20 extra", "c.21"}},
+		{"volumes of the same chapter", []string{"v.1 c.5", "v.2 c.5"}},
+		{"no chapter first", []string{"v.3", "c.1"}},
+	}
+	for _, tt := range tests {
+		keys := make([]string, len(tt.releases))
+		for i, r := range tt.releases {
+			keys[i] = ParseRelease(r).SortKey()
+		}
+		if !sort.StringsAreSorted(keys) {
+			t.Errorf("%s: SortKeys of %q are %q, which aren't in order", tt.name, tt.releases, keys)
+		}
+		for i := 1; i < len(keys); i++ {
+			if keys[i] == keys[i-1] {
+				t.Errorf("%s: %q and %q have the same SortKey %q", tt.name, tt.releases[i-1], tt.releases[i], keys[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package scrape
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danlock/feedgen/lib/logger"
+)
+
+const (
+	robotsPath = "/robots.txt"
+	// robotsTTL is how long a host's robots.txt is followed before it's fetched again
+	robotsTTL = 24 * time.Hour
+	// robotsRetry is how long to wait before trying again to fetch a robots.txt that couldn't be fetched
+	robotsRetry = 10 * time.Minute
+	// maxRobotsBytes is how much of a robots.txt is read, as recommended by RFC 9309
+	maxRobotsBytes = 500 << 10
+)
+
+// robotsRules are the rules in a host's robots.txt that apply to the Fetcher's User-Agent.
+type robotsRules struct {
+	expiresAt  time.Time
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+type robotsRule struct {
+	allow   bool
+	length  int
+	pattern *regexp.Regexp
+}
+
+// allowed reports whether the longest rule matching path allows it, ties going to Allow. Paths no rule matches are allowed.
+func (r *robotsRules) allowed(path string) bool {
+	allowed, longest := true, -1
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > longest || (rule.length == longest && rule.allow) {
+			allowed, longest = rule.allow, rule.length
+		}
+	}
+	return allowed
+}
+
+// allowedByRobots reports whether the robots.txt of u's host lets the Fetcher request u.
+func (f *Fetcher) allowedByRobots(ctx context.Context, u *url.URL) (bool, error) {
+	rules, err := f.robotsFor(ctx, u)
+	if err != nil {
+		return false, err
+	}
+	return rules.allowed(u.RequestURI()), nil
+}
+
+// robotsFor returns the robots.txt rules of u's host, fetching them if they haven't been yet or have expired.
+// Hosts without a robots.txt, or whose robots.txt can't be fetched, allow everything.
+func (f *Fetcher) robotsFor(ctx context.Context, u *url.URL) (*robotsRules, error) {
+	root := u.Scheme + "://" + u.Host
+	f.mu.Lock()
+	rules, ok := f.robots[root]
+	f.mu.Unlock()
+	now := time.Now()
+	if ok && now.Before(rules.expiresAt) {
+		return rules, nil
+	}
+
+	rules = &robotsRules{expiresAt: now.Add(robotsTTL)}
+	resp, err := f.Get(ctx, root+robotsPath)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		return nil, ctx.Err()
+	case err != nil:
+		logger.Warnf(ctx, "Failed fetching %s%s, allowing everything for now err: %+v", root, robotsPath, err)
+		rules.expiresAt = now.Add(robotsRetry)
+	case resp.StatusCode == http.StatusOK:
+		rules.rules, rules.crawlDelay = parseRobots(io.LimitReader(resp.Body, maxRobotsBytes), f.opts.UserAgent)
+		resp.Body.Close()
+	default:
+		if resp.StatusCode >= http.StatusInternalServerError {
+			rules.expiresAt = now.Add(robotsRetry)
+		}
+		resp.Body.Close()
+	}
+	if rules.crawlDelay > 0 {
+		f.bucket(u.Host).setRate(rules.crawlDelay)
+	}
+	f.mu.Lock()
+	f.robots[root] = rules
+	f.mu.Unlock()
+	return rules, nil
+}
+
+// parseRobots parses the rules and Crawl-delay of the robots.txt group for userAgent, falling back to the group for every agent.
+func parseRobots(r io.Reader, userAgent string) ([]robotsRule, time.Duration) {
+	// The product token is the name at the start of the User-Agent, like feedgen in feedgen/1.0
+	token := ""
+	if fields := strings.FieldsFunc(userAgent, func(r rune) bool { return r == '/' || r == ' ' }); len(fields) > 0 {
+		token = strings.ToLower(fields[0])
+	}
+	type group struct {
+		rules      []robotsRule
+		crawlDelay time.Duration
+	}
+	var ours, everyone *group
+	var current []*group
+	inAgents := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		colon := strings.IndexByte(line, ':')
+		if colon < 0 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(line[:colon]))
+		value := strings.TrimSpace(line[colon+1:])
+		switch key {
+		case "user-agent":
+			if !inAgents {
+				current = nil
+			}
+			inAgents = true
+			agent := strings.ToLower(value)
+			switch {
+			case agent == "*":
+				if everyone == nil {
+					everyone = &group{}
+				}
+				current = append(current, everyone)
+			case token != "" && agent == token:
+				if ours == nil {
+					ours = &group{}
+				}
+				current = append(current, ours)
+			}
+		case "allow", "disallow":
+			inAgents = false
+			if value == "" {
+				continue
+			}
+			rule := robotsRule{allow: key == "allow", length: len(value), pattern: robotsPattern(value)}
+			for _, g := range current {
+				g.rules = append(g.rules, rule)
+			}
+		case "crawl-delay":
+			inAgents = false
+			seconds, err := strconv.ParseFloat(value, 64)
+			if err != nil || seconds <= 0 {
+				continue
+			}
+			for _, g := range current {
+				g.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		default:
+			inAgents = false
+		}
+	}
+	if ours == nil {
+		ours = everyone
+	}
+	if ours == nil {
+		return nil, 0
+	}
+	return ours.rules, ours.crawlDelay
+}
+
+// robotsPattern compiles a robots.txt path, where * matches anything and a trailing $ anchors the end of the path.
+func robotsPattern(path string) *regexp.Regexp {
+	anchored := strings.HasSuffix(path, "$")
+	path = strings.TrimSuffix(path, "$")
+	pattern := "^" + strings.Replace(regexp.QuoteMeta(path), `\*`, ".*", -1)
+	if anchored {
+		pattern += "$"
+	}
+	return regexp.MustCompile(pattern)
+}
@@ -0,0 +1,73 @@
+package scrape
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/danlock/feedgen/lib/logger"
+)
+
+// SnapshotKind is the kind of page a Snapshot is of, which decides how it's parsed.
+type SnapshotKind string
+
+const (
+	// SnapshotRecentReleases is a page of the releases a Source currently lists as recent
+	SnapshotRecentReleases SnapshotKind = "recent_releases"
+	// SnapshotArchiveReleases is a page of the releases a Source recorded on Snapshot.Day
+	SnapshotArchiveReleases SnapshotKind = "archive_releases"
+	// SnapshotSeries is the series page of Snapshot.MUID
+	SnapshotSeries SnapshotKind = "series"
+)
+
+// Snapshot is a page exactly as a Source downloaded it, kept so it can be parsed again once a parser is fixed.
+type Snapshot struct {
+	Source string
+	Kind   SnapshotKind
+	URL    string
+	// MUID is set for series pages, Day for archived release pages
+	MUID      int
+	Day       time.Time
+	Body      []byte
+	FetchedAt time.Time
+}
+
+// SnapshotStore keeps the Snapshots taken by Sources.
+type SnapshotStore interface {
+	SaveSnapshot(ctx context.Context, snap Snapshot) error
+}
+
+// SnapshotParser is a Source that can parse the Snapshots it took again without fetching anything.
+type SnapshotParser interface {
+	Source
+	// ParseReleasesSnapshot parses a SnapshotRecentReleases or SnapshotArchiveReleases page.
+	ParseReleasesSnapshot(snap Snapshot) ([]MangaRelease, error)
+	// ParseSeriesSnapshot parses a SnapshotSeries page the way GetMangaInfo would have.
+	ParseSeriesSnapshot(snap Snapshot) (MangaInfo, error)
+}
+
+var (
+	snapshotStoreMu sync.RWMutex
+	snapshotStore   SnapshotStore
+)
+
+// SetSnapshotStore makes Sources save every page they download to s. Pages aren't kept if it's never called.
+func SetSnapshotStore(s SnapshotStore) {
+	snapshotStoreMu.Lock()
+	defer snapshotStoreMu.Unlock()
+	snapshotStore = s
+}
+
+// saveSnapshot saves snap to the SnapshotStore if there is one.
+// Failing to save a snapshot only loses the chance to reparse the page later, so it doesn't fail the scrape.
+func saveSnapshot(ctx context.Context, snap Snapshot) {
+	snapshotStoreMu.RLock()
+	store := snapshotStore
+	snapshotStoreMu.RUnlock()
+	if store == nil {
+		return
+	}
+	if err := store.SaveSnapshot(ctx, snap); err != nil {
+		logger.Errf(ctx, "Failed to save snapshot of %s err: %+v", snap.URL, err)
+	}
+}
@@ -0,0 +1,158 @@
+package scrape
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/danlock/feedgen/lib"
+	"github.com/danlock/feedgen/lib/logger"
+	"github.com/pkg/errors"
+)
+
+// Source is a provider of manga releases and series info, such as MangaUpdates.
+type Source interface {
+	// Name uniquely identifies the Source for configuration and logging.
+	Name() string
+	// QueryRecentReleases returns the releases the Source currently lists as recent.
+	QueryRecentReleases(ctx context.Context) ([]MangaRelease, error)
+	// GetMangaInfo fetches the series info for id, returning ErrInvalidMUID if the series doesn't exist
+	// or a *MergedError if it was merged into another series.
+	GetMangaInfo(ctx context.Context, id int) (MangaInfo, error)
+	// SeriesURL returns the canonical URL of the series page for id.
+	SeriesURL(id int) string
+}
+
+// ArchiveSource is a Source that can also list the releases from any past day, which is used to backfill gaps in polling.
+type ArchiveSource interface {
+	Source
+	// QueryReleasesOn returns every release the Source recorded on the given day.
+	QueryReleasesOn(ctx context.Context, day time.Time) ([]MangaRelease, error)
+}
+
+// SequentialSource is a Source that numbers its series in order, so every series can be scraped by walking ranges of ids with populate-db.
+type SequentialSource interface {
+	Source
+	// SequentialIDs marks the Source's ids as sequential.
+	SequentialIDs()
+}
+
+// SeriesMatch is a series found by searching a Source for a title.
+type SeriesMatch struct {
+	MUID  int
+	Title string
+}
+
+// TitleSearcher is a Source that can search for series by title, which is used to find the series of releases that don't link to it.
+type TitleSearcher interface {
+	Source
+	// SearchSeries returns the series matching title, with the title each one matched on when the Source says.
+	SearchSeries(ctx context.Context, title string) ([]SeriesMatch, error)
+}
+
+// SeriesURLParser is a Source that can tell which series a URL links to, which lets feeds be made from bookmarked series pages.
+type SeriesURLParser interface {
+	Source
+	// ParseSeriesURL returns the id of the series u links to, or ErrNotSeriesURL if it isn't a series page this Source can identify.
+	// Links using another Source's ids may be looked up to find the series.
+	ParseSeriesURL(ctx context.Context, u *url.URL) (int, error)
+}
+
+const ErrUnknownSource lib.SentinelError = "Unknown source"
+const ErrNotSeriesURL lib.SentinelError = "Not a series URL"
+
+// MaxMergeChain is how many merges in a row are followed to find the series that's left, in case merges ever loop.
+const MaxMergeChain = 5
+
+// MergedError means a series was merged into another one, which should be used in its place.
+type MergedError struct {
+	MUID       int
+	MergedInto int
+}
+
+func (e *MergedError) Error() string {
+	return fmt.Sprintf("Series %d was merged into %d", e.MUID, e.MergedInto)
+}
+
+// MergedInto returns the id of the series that err says another was merged into, or 0 if err isn't a *MergedError.
+func MergedInto(err error) int {
+	if merged, ok := errors.Cause(err).(*MergedError); ok {
+		return merged.MergedInto
+	}
+	return 0
+}
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = make(map[string]Source)
+)
+
+// RegisterSource makes a Source available by name. Registering a Source with the same name replaces the old one.
+func RegisterSource(s Source) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	sources[s.Name()] = s
+}
+
+// GetSource returns the registered Source with the given name.
+func GetSource(name string) (Source, error) {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	s, ok := sources[name]
+	if !ok {
+		return nil, ErrUnknownSource
+	}
+	return s, nil
+}
+
+// PollForReleases queries src for recent releases immediately and then every freq, sending each batch on the returned channel.
+// Every attempt is passed to report if it isn't nil, including the ones that failed. The channel is closed once ctx is done.
+func PollForReleases(ctx context.Context, src Source, freq time.Duration, report func(ScrapeRun)) <-chan []MangaRelease {
+	out := make(chan []MangaRelease)
+	timer := time.NewTicker(freq)
+	pollFunc := func() {
+		start := time.Now()
+		releases, err := src.QueryRecentReleases(ctx)
+		run := ScrapeRun{Source: src.Name(), StartedAt: start.UTC(), Duration: time.Since(start), Releases: len(releases)}
+		for _, r := range releases {
+			if r.MUID > 0 {
+				run.WithMUID++
+			}
+		}
+		if err != nil {
+			run.Error = err.Error()
+		}
+		if report != nil && ctx.Err() == nil {
+			report(run)
+		}
+		if err != nil {
+			logger.Errf(ctx, "Failed to get releases from %s! %+v", src.Name(), err)
+			return
+		}
+		logger.Dbgf(ctx, "Scraped %d %s releases in %s", len(releases), src.Name(), run.Duration.String())
+		select {
+		case <-ctx.Done():
+			return
+		case out <- releases:
+		}
+	}
+	go func() {
+		defer func() {
+			close(out)
+			timer.Stop()
+		}()
+		// Poll immediately on startup instead of waiting for poll duration
+		pollFunc()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				pollFunc()
+			}
+		}
+	}()
+	return out
+}